@@ -16,17 +16,17 @@
 package main
 
 import (
-	"bytes"
+	"context"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"net/http"
+	"time"
 
 	"github.com/hyperledger/transact-sdk-go/sabre"
 	"github.com/hyperledger/transact-sdk-go/sabre/addressing"
 	"github.com/hyperledger/transact-sdk-go/src/protobuf/sabre_pb2"
 	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transact"
 	"github.com/hyperledger/transact-sdk-go/transactions"
 	t "github.com/hyperledger/transact-sdk-go/transactions"
 	"github.com/hyperledger/transact-sdk-go/transactions/signing"
@@ -70,14 +70,14 @@ func createTransaction(sabrePayloadBuilder sabre.ISabrePayloadBuilder,
 	return sabreTxnBuilder.Build(signer)
 }
 
-func createBatch(txns []*transaction_pb2.Transaction, signer *signing.Signer) ([]byte, error) {
+func createBatch(txns []*transaction_pb2.Transaction, signer *signing.Signer) (*transaction_pb2.Batch, error) {
 	batchBuilder, err := transactions.NewBatchBuilder(
 		transactions.WithTransactions(txns),
 	)
 	if err != nil {
 		return nil, err
 	}
-	return batchBuilder.Build(signer)
+	return batchBuilder.BuildBatch(signer)
 }
 
 type Request struct {
@@ -88,6 +88,9 @@ type Request struct {
 	Space          string
 	UserPrivateKey string
 	XOVersion      string
+	Wait           time.Duration
+	Attempts       int
+	Timeout        time.Duration
 }
 
 func main() {
@@ -100,16 +103,37 @@ func main() {
 	flag.StringVar(&r.Space, "space", "", "The space taken in the execute contract")
 	flag.StringVar(&r.UserPrivateKey, "key", "", "The signing user's private key")
 	flag.StringVar(&r.XOVersion, "xo_version", "0.3.3", "version of the XO contract")
+	flag.DurationVar(&r.Wait, "wait", 30*time.Second, "How long to wait for the batch to commit")
+	flag.IntVar(&r.Attempts, "attempts", 5, "How many times to attempt each request")
+	flag.DurationVar(&r.Timeout, "timeout", time.Minute, "How long to try before giving up")
 	flag.Parse()
 
-	endpoint := fmt.Sprintf("http://%s/scabbard/%s/%s/batches", r.SplinterHost, r.CircuitID, r.ServiceID)
-	log.Println(endpoint)
+	ctx, cancel := context.WithTimeout(context.Background(), r.Timeout)
+	defer cancel()
 
-	signer := signing.NewCryptoFactory(
-		signing.CreateContext("secp256k1"),
-	).NewSigner(
-		signing.NewSecp256k1PrivateKey([]byte(r.UserPrivateKey)),
-	)
+	backend, err := transact.NewSubmitter(transact.Config{
+		Backend:   transact.BackendScabbard,
+		URL:       "http://" + r.SplinterHost,
+		CircuitID: r.CircuitID,
+		ServiceID: r.ServiceID,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	submitter, err := transact.NewRetrySubmitter(backend, transact.WithMaxAttempts(r.Attempts))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cryptoContext, err := signing.NewContext("secp256k1")
+	if err != nil {
+		log.Fatal(err)
+	}
+	privateKey, err := signing.NewSecp256k1PrivateKeyFromHex(r.UserPrivateKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+	signer := signing.NewCryptoFactory(cryptoContext).NewSigner(privateKey)
 
 	payload, err := createPayload(r.XOVersion, r.GameName, r.Space)
 	if err != nil {
@@ -120,14 +144,22 @@ func main() {
 		log.Fatal(err)
 	}
 
-	batches, err := createBatch([]*transaction_pb2.Transaction{txn}, signer)
-	var b = bytes.NewBuffer(batches)
+	batch, err := createBatch([]*transaction_pb2.Transaction{txn}, signer)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	resp, err := http.Post(endpoint, "application/octet-stream", b)
+	ids, err := submitter.Submit(ctx, batch)
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer resp.Body.Close()
-	message, _ := ioutil.ReadAll(resp.Body)
-	fmt.Printf(string(message))
+	log.Println(ids)
+
+	statuses, err := submitter.WaitForStatus(ctx, ids, r.Wait)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, status := range statuses {
+		fmt.Printf("%s: %s\n", status.ID, status.Status)
+	}
 }
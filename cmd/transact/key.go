@@ -0,0 +1,201 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+const (
+	formatAuto = "auto"
+	formatHex  = "hex"
+	formatPem  = "pem"
+	formatRaw  = "raw"
+)
+
+// passwordUsage describes the password flags, which name where to read a
+// password from rather than taking it on the command line, where other
+// users can see it
+const passwordUsage = "as env:VAR to read it from an environment variable, or file:PATH to read it from a file"
+
+func runKeyShow(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("key show", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", formatAuto, "format of the key file: auto, hex, pem or raw")
+	password := flags.String("password", "", "password for an encrypted PEM key file, "+passwordUsage)
+	showPrivate := flags.Bool("private", false, "also print the private key as hex")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: transact key show [flags] FILE")
+		flags.PrintDefaults()
+	}
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errUsage
+	}
+
+	pass, err := readPassword(*password)
+	if err != nil {
+		return err
+	}
+	priv, err := readPrivateKey(flags.Arg(0), *format, pass)
+	if err != nil {
+		return err
+	}
+	pub := signing.NewSecp256k1Context().GetPublicKey(priv)
+
+	fmt.Fprintln(stdout, "algorithm:  ", priv.GetAlgorithmName())
+	fmt.Fprintln(stdout, "public key: ", pub.AsHex())
+	if *showPrivate {
+		fmt.Fprintln(stdout, "private key:", priv.AsHex())
+	}
+	return nil
+}
+
+func runKeyConvert(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("key convert", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	from := flags.String("from", formatAuto, "format of the input key file: auto, hex, pem or raw")
+	to := flags.String("to", formatHex, "format of the output: hex, pem or raw")
+	out := flags.String("out", "", "output file (default stdout)")
+	password := flags.String("password", "", "password for an encrypted PEM input, "+passwordUsage)
+	outPassword := flags.String("out-password", "", "password to encrypt a PEM output, "+passwordUsage)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: transact key convert [flags] FILE")
+		flags.PrintDefaults()
+	}
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errUsage
+	}
+
+	pass, err := readPassword(*password)
+	if err != nil {
+		return err
+	}
+	outPass, err := readPassword(*outPassword)
+	if err != nil {
+		return err
+	}
+
+	priv, err := readPrivateKey(flags.Arg(0), *from, pass)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	switch *to {
+	case formatHex:
+		data = []byte(priv.AsHex() + "\n")
+	case formatRaw:
+		data = priv.AsBytes()
+	case formatPem:
+		pem, err := signing.Secp256k1PrivateKeyToPem(priv, outPass)
+		if err != nil {
+			return err
+		}
+		data = []byte(pem)
+	default:
+		return fmt.Errorf("unknown output format %q", *to)
+	}
+
+	if *out == "" {
+		_, err := stdout.Write(data)
+		return err
+	}
+	return writeKeyFile(*out, data, privateKeyFileMode, true)
+}
+
+// readPassword returns the password from the given source: env:VAR for
+// an environment variable, or file:PATH for the first line of a file. An
+// empty source is an empty password.
+func readPassword(source string) (string, error) {
+	switch {
+	case source == "":
+		return "", nil
+	case strings.HasPrefix(source, "env:"):
+		name := strings.TrimPrefix(source, "env:")
+		password, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("password environment variable %s is not set", name)
+		}
+		return password, nil
+	case strings.HasPrefix(source, "file:"):
+		data, err := ioutil.ReadFile(strings.TrimPrefix(source, "file:"))
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(strings.SplitN(string(data), "\n", 2)[0], "\r"), nil
+	}
+
+	// The source is not echoed, since it is likely a mistyped password
+	return "", fmt.Errorf("password must be given %s", passwordUsage)
+}
+
+// readPrivateKey loads a secp256k1 private key from a file in the given
+// format, detecting the format from the contents when it is auto
+func readPrivateKey(path, format, password string) (signing.PrivateKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == formatAuto {
+		format = detectFormat(data)
+	}
+
+	switch format {
+	case formatHex:
+		return signing.NewSecp256k1PrivateKeyFromHex(string(data))
+	case formatPem:
+		return signing.PemToSecp256k1PrivateKey(string(data), password)
+	case formatRaw:
+		if len(data) != 32 {
+			return nil, fmt.Errorf("%s is not a raw private key: got %d bytes; expected 32", path, len(data))
+		}
+		return signing.NewSecp256k1PrivateKeyFromHex(hex.EncodeToString(data))
+	}
+
+	return nil, fmt.Errorf("unknown input format %q", format)
+}
+
+// detectFormat guesses the format of a key file from its contents
+func detectFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("-----BEGIN")) {
+		return formatPem
+	}
+	if _, err := hex.DecodeString(string(trimmed)); err == nil && len(trimmed) == 64 {
+		return formatHex
+	}
+	return formatRaw
+}
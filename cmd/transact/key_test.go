@@ -0,0 +1,116 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	PRIVHEX = "8486444be50722d19db868be92bcc12779867ca6b58f2175008ebd9438ef70a9"
+	PUBHEX  = "03582e93c8cd63a58bb77ff18622e92d0e4e27335ab239ff4f10f1e446437cccad"
+)
+
+func writeFile(t *testing.T, path string, data string) {
+	if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	hexPath := filepath.Join(dir, "key.priv")
+	writeFile(t, hexPath, PRIVHEX+"\n")
+
+	for _, format := range []string{formatHex, formatPem, formatRaw} {
+		converted := filepath.Join(dir, "key."+format)
+		if _, err := runCommand(t, "key", "convert", "-to", format, "-out", converted, hexPath); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		info, err := os.Stat(converted)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("%s: expected mode 600, got %o", format, info.Mode().Perm())
+		}
+
+		// Detect the format and convert back to hex
+		out, err := runCommand(t, "key", "convert", converted)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if out != PRIVHEX+"\n" {
+			t.Errorf("%s: expected %s, got %q", format, PRIVHEX, out)
+		}
+	}
+}
+
+func TestConvertEncryptedPem(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	hexPath := filepath.Join(dir, "key.priv")
+	pemPath := filepath.Join(dir, "key.pem")
+	passwordPath := filepath.Join(dir, "password")
+	writeFile(t, hexPath, PRIVHEX+"\n")
+	writeFile(t, passwordPath, "secret\n")
+
+	os.Setenv("TRANSACT_TEST_PASSWORD", "secret")
+	defer os.Unsetenv("TRANSACT_TEST_PASSWORD")
+
+	if _, err := runCommand(t, "key", "convert", "-to", "pem", "-out-password", "env:TRANSACT_TEST_PASSWORD",
+		"-out", pemPath, hexPath); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(readKeyFile(t, pemPath), "ENCRYPTED") {
+		t.Error("Expected an encrypted PEM")
+	}
+
+	out, err := runCommand(t, "key", "show", "-password", "file:"+passwordPath, pemPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, PUBHEX) {
+		t.Errorf("Expected public key %s in %q", PUBHEX, out)
+	}
+
+	if _, err := runCommand(t, "key", "show", pemPath); err == nil {
+		t.Error("Expected an error reading an encrypted PEM without a password")
+	}
+}
+
+func TestPasswordSources(t *testing.T) {
+	if _, err := readPassword("secret"); err == nil {
+		t.Error("Expected a password given directly to be rejected")
+	} else if strings.Contains(err.Error(), "secret") {
+		t.Error("Expected the error not to echo the password")
+	}
+
+	if _, err := readPassword("env:TRANSACT_TEST_UNSET_PASSWORD"); err == nil {
+		t.Error("Expected an error for an unset environment variable")
+	}
+
+	if password, err := readPassword(""); err != nil || password != "" {
+		t.Errorf("Expected an empty password, got %q, %v", password, err)
+	}
+}
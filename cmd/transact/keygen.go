@@ -0,0 +1,131 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+const (
+	// privateKeyFileMode is the permission mode for private key files
+	privateKeyFileMode = 0600
+
+	// publicKeyFileMode is the permission mode for public key files
+	publicKeyFileMode = 0644
+)
+
+func runKeygen(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("keygen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	keyDir := flags.String("key-dir", "", "directory for the key files (default $HOME/.transact/keys)")
+	force := flags.Bool("force", false, "overwrite existing key files")
+	quiet := flags.Bool("quiet", false, "do not print the key file paths")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: transact keygen [flags] [name]")
+		flags.PrintDefaults()
+	}
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	name, err := keyName(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	dir := *keyDir
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		dir = filepath.Join(home, ".transact", "keys")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	context := signing.NewSecp256k1Context()
+	priv := context.NewRandomPrivateKey()
+	pub := context.GetPublicKey(priv)
+
+	privPath := filepath.Join(dir, name+".priv")
+	pubPath := filepath.Join(dir, name+".pub")
+
+	// Check both files up front so that an existing public key does not
+	// leave a new private key behind
+	if !*force {
+		for _, path := range []string{privPath, pubPath} {
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("file exists: %s; use -force to overwrite", path)
+			}
+		}
+	}
+
+	if err := writeKeyFile(privPath, []byte(priv.AsHex()+"\n"), privateKeyFileMode, *force); err != nil {
+		return err
+	}
+	if err := writeKeyFile(pubPath, []byte(pub.AsHex()+"\n"), publicKeyFileMode, *force); err != nil {
+		return err
+	}
+
+	if !*quiet {
+		fmt.Fprintln(stdout, "writing file:", privPath)
+		fmt.Fprintln(stdout, "writing file:", pubPath)
+	}
+	return nil
+}
+
+// keyName returns the given key name, defaulting to the current user name
+func keyName(name string) (string, error) {
+	if name != "" {
+		return name, nil
+	}
+	current, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("no key name given and current user is unknown: %v", err)
+	}
+	return current.Username, nil
+}
+
+// writeKeyFile writes data to path with the given permission mode. Unless
+// overwrite is set, it fails if the file already exists.
+func writeKeyFile(path string, data []byte, mode os.FileMode, overwrite bool) error {
+	openFlags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !overwrite {
+		openFlags |= os.O_EXCL
+	}
+	file, err := os.OpenFile(path, openFlags, mode)
+	if err != nil {
+		return err
+	}
+	if err := file.Chmod(mode); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,126 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "transact-cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func runCommand(t *testing.T, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	err := run(args, &stdout, &stderr)
+	return stdout.String(), err
+}
+
+func readKeyFile(t *testing.T, path string) string {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestKeygen(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	out, err := runCommand(t, "keygen", "-key-dir", dir, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	privPath := filepath.Join(dir, "alice.priv")
+	pubPath := filepath.Join(dir, "alice.pub")
+	if !strings.Contains(out, privPath) || !strings.Contains(out, pubPath) {
+		t.Errorf("Expected the key file paths in the output, got %q", out)
+	}
+
+	privHex := readKeyFile(t, privPath)
+	pubHex := readKeyFile(t, pubPath)
+	if !strings.HasSuffix(privHex, "\n") || !strings.HasSuffix(pubHex, "\n") {
+		t.Error("Expected key files to end with a newline")
+	}
+
+	priv, err := signing.NewSecp256k1PrivateKeyFromHex(strings.TrimSpace(privHex))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := signing.NewSecp256k1Context().GetPublicKey(priv)
+	if pub.AsHex() != strings.TrimSpace(pubHex) {
+		t.Errorf("Public key file %s does not match the private key's %s", pubHex, pub.AsHex())
+	}
+
+	for path, mode := range map[string]os.FileMode{privPath: 0600, pubPath: 0644} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != mode {
+			t.Errorf("Expected %s to have mode %o, got %o", path, mode, info.Mode().Perm())
+		}
+	}
+}
+
+func TestKeygenRefusesOverwrite(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	if _, err := runCommand(t, "keygen", "-quiet", "-key-dir", dir, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	original := readKeyFile(t, filepath.Join(dir, "alice.priv"))
+
+	if _, err := runCommand(t, "keygen", "-quiet", "-key-dir", dir, "alice"); err == nil {
+		t.Error("Expected an error generating over existing key files")
+	}
+	if readKeyFile(t, filepath.Join(dir, "alice.priv")) != original {
+		t.Error("Existing private key was overwritten without -force")
+	}
+
+	if _, err := runCommand(t, "keygen", "-quiet", "-force", "-key-dir", dir, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if readKeyFile(t, filepath.Join(dir, "alice.priv")) == original {
+		t.Error("Expected -force to replace the private key")
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"key"},
+		{"key", "show"},
+		{"keygen", "-no-such-flag"},
+	} {
+		if _, err := runCommand(t, args...); err != errUsage {
+			t.Errorf("%v: expected errUsage, got %v", args, err)
+		}
+	}
+}
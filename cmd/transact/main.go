@@ -0,0 +1,98 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+// Command transact provides key management for Transact SDK users.
+//
+// Usage:
+//
+//	transact keygen [flags] [name]
+//	transact key show [flags] FILE
+//	transact key convert [flags] FILE
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `Usage: transact <command> [arguments]
+
+Commands:
+  keygen         generate a secp256k1 key pair as <name>.priv and <name>.pub
+  key show       print the public key (and optionally private key) of a key file
+  key convert    convert a private key between hex, pem and raw formats
+
+Run 'transact <command> -h' for command flags.
+`
+
+// errUsage is returned for a command line that cannot be run, after the
+// relevant usage has been printed
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	switch err := run(os.Args[1:], os.Stdout, os.Stderr); err {
+	case nil:
+	case flag.ErrHelp:
+	case errUsage:
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return errUsage
+	}
+
+	switch args[0] {
+	case "keygen":
+		return runKeygen(args[1:], stdout, stderr)
+	case "key":
+		if len(args) < 2 {
+			fmt.Fprint(stderr, usage)
+			return errUsage
+		}
+		switch args[1] {
+		case "show":
+			return runKeyShow(args[2:], stdout, stderr)
+		case "convert":
+			return runKeyConvert(args[2:], stdout, stderr)
+		}
+		return fmt.Errorf("unknown key command %q", args[1])
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	}
+
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+// parseFlags parses the command's flags, returning flag.ErrHelp for -h
+// and errUsage for flags that cannot be parsed
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+		return errUsage
+	}
+	return nil
+}
@@ -73,3 +73,653 @@ type ProtobufEncodingError struct {
 func (p ProtobufEncodingError) Error() string {
 	return fmt.Sprintf("protobuf encoding error: %s", p.err)
 }
+
+// -- Invalid Address --
+
+// NewInvalidAddressError returns a new InvalidAddressError provided
+// the offending address {string}
+func NewInvalidAddressError(address string) InvalidAddressError {
+	return InvalidAddressError{address}
+}
+
+// InvalidAddressError is the error for a malformed state address
+type InvalidAddressError struct {
+	address string
+}
+
+// Error returns the error {string} for an InvalidAddressError
+func (i InvalidAddressError) Error() string {
+	return fmt.Sprintf("invalid address: %q; expected at least 6 hex characters", i.address)
+}
+
+// -- Protobuf Unmarshalling --
+
+// NewProtobufDecodingError returns a new ProtobufDecodingError provided
+// the error from proto library
+func NewProtobufDecodingError(err error) ProtobufDecodingError {
+	return ProtobufDecodingError{err: err.Error()}
+}
+
+// ProtobufDecodingError is the error for when a protobuf fails decoding
+type ProtobufDecodingError struct {
+	err string
+}
+
+// Error returns the error {string} for a ProtobufDecodingError
+func (p ProtobufDecodingError) Error() string {
+	return fmt.Sprintf("protobuf decoding error: %s", p.err)
+}
+
+// -- Batcher Mismatch --
+
+// NewBatcherMismatchError returns a new BatcherMismatchError provided the
+// transaction id {string}, the batch signer's public key {string} and the
+// batcher public key declared by the transaction {string}
+func NewBatcherMismatchError(transactionID, batchSigner, batcher string) BatcherMismatchError {
+	return BatcherMismatchError{transactionID, batchSigner, batcher}
+}
+
+// BatcherMismatchError is the error for a transaction whose batcher public
+// key does not match the signer of its batch
+type BatcherMismatchError struct {
+	transactionID string
+	batchSigner   string
+	batcher       string
+}
+
+// TransactionID returns the header signature of the offending transaction
+func (b BatcherMismatchError) TransactionID() string {
+	return b.transactionID
+}
+
+// Error returns the error {string} for a BatcherMismatchError
+func (b BatcherMismatchError) Error() string {
+	return fmt.Sprintf("transaction %s declares batcher %s but batch is signed by %s",
+		b.transactionID, b.batcher, b.batchSigner)
+}
+
+// -- Payload Hash Mismatch --
+
+// NewPayloadHashMismatchError returns a new PayloadHashMismatchError provided
+// the transaction id {string}
+func NewPayloadHashMismatchError(transactionID string) PayloadHashMismatchError {
+	return PayloadHashMismatchError{transactionID}
+}
+
+// PayloadHashMismatchError is the error for a transaction whose payload does
+// not match the payload_sha512 declared in its header
+type PayloadHashMismatchError struct {
+	transactionID string
+}
+
+// TransactionID returns the header signature of the offending transaction
+func (p PayloadHashMismatchError) TransactionID() string {
+	return p.transactionID
+}
+
+// Error returns the error {string} for a PayloadHashMismatchError
+func (p PayloadHashMismatchError) Error() string {
+	return fmt.Sprintf("transaction %s payload does not match payload_sha512", p.transactionID)
+}
+
+// -- Invalid Signature --
+
+// NewInvalidSignatureError returns a new InvalidSignatureError provided
+// the header signature {string} that failed verification
+func NewInvalidSignatureError(headerSignature string) InvalidSignatureError {
+	return InvalidSignatureError{headerSignature}
+}
+
+// InvalidSignatureError is the error for a header signature that was not
+// produced by the header's signer
+type InvalidSignatureError struct {
+	headerSignature string
+}
+
+// HeaderSignature returns the offending header signature
+func (i InvalidSignatureError) HeaderSignature() string {
+	return i.headerSignature
+}
+
+// Error returns the error {string} for an InvalidSignatureError
+func (i InvalidSignatureError) Error() string {
+	return fmt.Sprintf("invalid header signature: %s", i.headerSignature)
+}
+
+// -- Invalid Public Key --
+
+// NewInvalidPublicKeyError returns a new InvalidPublicKeyError provided
+// the malformed public key {string}
+func NewInvalidPublicKeyError(publicKey string) InvalidPublicKeyError {
+	return InvalidPublicKeyError{publicKey}
+}
+
+// InvalidPublicKeyError is the error for a public key that cannot be decoded
+type InvalidPublicKeyError struct {
+	publicKey string
+}
+
+// Error returns the error {string} for an InvalidPublicKeyError
+func (i InvalidPublicKeyError) Error() string {
+	return fmt.Sprintf("invalid public key: %q", i.publicKey)
+}
+
+// -- Transaction ID Mismatch --
+
+// NewTransactionIDMismatchError returns a new TransactionIDMismatchError
+// provided the batch id {string}, the position {int} of the mismatch and the
+// transaction ids {string} expected by the header and found in the batch.
+// An empty id means the batch has fewer transactions or ids than the other.
+func NewTransactionIDMismatchError(batchID string, index int, expected, actual string) TransactionIDMismatchError {
+	return TransactionIDMismatchError{batchID, index, expected, actual}
+}
+
+// TransactionIDMismatchError is the error for a batch whose transactions do
+// not match the transaction_ids listed in its header
+type TransactionIDMismatchError struct {
+	batchID  string
+	index    int
+	expected string
+	actual   string
+}
+
+// BatchID returns the header signature of the offending batch
+func (t TransactionIDMismatchError) BatchID() string {
+	return t.batchID
+}
+
+// Error returns the error {string} for a TransactionIDMismatchError
+func (t TransactionIDMismatchError) Error() string {
+	return fmt.Sprintf("batch %s transaction %d: header lists %q but batch contains %q",
+		t.batchID, t.index, t.expected, t.actual)
+}
+
+// -- Transaction Build --
+
+// NewTransactionBuildError returns a new TransactionBuildError provided
+// the index {int} of the builder that failed and the error it returned
+func NewTransactionBuildError(index int, err error) TransactionBuildError {
+	return TransactionBuildError{index: index, err: err}
+}
+
+// TransactionBuildError is the error for a transaction that failed to
+// build while building many transactions
+type TransactionBuildError struct {
+	index int
+	err   error
+}
+
+// Index returns the index of the builder that failed
+func (t TransactionBuildError) Index() int {
+	return t.index
+}
+
+// Error returns the error {string} for a TransactionBuildError
+func (t TransactionBuildError) Error() string {
+	return fmt.Sprintf("failed to build transaction %d: %s", t.index, t.err)
+}
+
+// Unwrap returns the error that caused the transaction to fail to build
+func (t TransactionBuildError) Unwrap() error {
+	return t.err
+}
+
+// -- Invalid Batch Limit --
+
+// NewInvalidBatchLimitError returns a new InvalidBatchLimitError provided
+// the name {string} of the limit and the rejected value {int}
+func NewInvalidBatchLimitError(limit string, value int) InvalidBatchLimitError {
+	return InvalidBatchLimitError{limit, value}
+}
+
+// InvalidBatchLimitError is the error for a batching limit that is not positive
+type InvalidBatchLimitError struct {
+	limit string
+	value int
+}
+
+// Error returns the error {string} for an InvalidBatchLimitError
+func (i InvalidBatchLimitError) Error() string {
+	return fmt.Sprintf("invalid %s: %d; must be greater than zero", i.limit, i.value)
+}
+
+// -- Batch Limit Exceeded --
+
+// NewBatchLimitExceededError returns a new BatchLimitExceededError provided
+// the ID {string} of the first transaction in the group that cannot be
+// batched, the name {string} of the limit, the limit {int}, and the
+// group's size {int}
+func NewBatchLimitExceededError(txnID string, limit string, max int, size int) BatchLimitExceededError {
+	return BatchLimitExceededError{txnID, limit, max, size}
+}
+
+// BatchLimitExceededError is the error for a transaction, or a group of
+// transactions that must stay in one batch, that is too large for a batch
+type BatchLimitExceededError struct {
+	txnID string
+	limit string
+	max   int
+	size  int
+}
+
+// TransactionID returns the ID of the first transaction in the group
+func (b BatchLimitExceededError) TransactionID() string {
+	return b.txnID
+}
+
+// Error returns the error {string} for a BatchLimitExceededError
+func (b BatchLimitExceededError) Error() string {
+	return fmt.Sprintf("transactions starting at %s need %d; exceeds %s of %d",
+		b.txnID, b.size, b.limit, b.max)
+}
+
+// -- Transaction Graph --
+
+// NewDuplicateNodeError returns a new DuplicateNodeError provided
+// the node ID {string} that was added twice
+func NewDuplicateNodeError(id string) DuplicateNodeError {
+	return DuplicateNodeError{id}
+}
+
+// DuplicateNodeError is the error for a graph node ID that is already in use
+type DuplicateNodeError struct {
+	id string
+}
+
+// Error returns the error {string} for a DuplicateNodeError
+func (d DuplicateNodeError) Error() string {
+	return fmt.Sprintf("duplicate graph node: %s", d.id)
+}
+
+// NewUnknownNodeError returns a new UnknownNodeError provided
+// the node ID {string} that is not in the graph
+func NewUnknownNodeError(id string) UnknownNodeError {
+	return UnknownNodeError{id}
+}
+
+// UnknownNodeError is the error for a dependency on a graph node that
+// was never added
+type UnknownNodeError struct {
+	id string
+}
+
+// Error returns the error {string} for an UnknownNodeError
+func (u UnknownNodeError) Error() string {
+	return fmt.Sprintf("unknown graph node: %s", u.id)
+}
+
+// NewDependencyCycleError returns a new DependencyCycleError provided
+// the IDs {[]string} of the nodes that could not be ordered
+func NewDependencyCycleError(ids []string) DependencyCycleError {
+	return DependencyCycleError{ids}
+}
+
+// DependencyCycleError is the error for graph nodes whose dependencies
+// form a cycle
+type DependencyCycleError struct {
+	ids []string
+}
+
+// NodeIDs returns the IDs of the nodes on or behind a cycle
+func (d DependencyCycleError) NodeIDs() []string {
+	return d.ids
+}
+
+// Error returns the error {string} for a DependencyCycleError
+func (d DependencyCycleError) Error() string {
+	return fmt.Sprintf("dependency cycle among graph nodes: %v", d.ids)
+}
+
+// -- Unknown Algorithm --
+
+// NewUnknownAlgorithmError returns a new UnknownAlgorithmError provided
+// the requested algorithm name {string}
+func NewUnknownAlgorithmError(algorithm string) UnknownAlgorithmError {
+	return UnknownAlgorithmError{algorithm}
+}
+
+// UnknownAlgorithmError is the error for a signing algorithm with no context
+type UnknownAlgorithmError struct {
+	algorithm string
+}
+
+// Error returns the error {string} for an UnknownAlgorithmError
+func (u UnknownAlgorithmError) Error() string {
+	return fmt.Sprintf("no such signing algorithm: %q", u.algorithm)
+}
+
+// -- Invalid Private Key --
+
+// NewInvalidPrivateKeyError returns a new InvalidPrivateKeyError provided
+// the reason {string} the key was rejected
+func NewInvalidPrivateKeyError(reason string) InvalidPrivateKeyError {
+	return InvalidPrivateKeyError{reason}
+}
+
+// InvalidPrivateKeyError is the error for a private key that cannot be used
+type InvalidPrivateKeyError struct {
+	reason string
+}
+
+// Error returns the error {string} for an InvalidPrivateKeyError
+func (i InvalidPrivateKeyError) Error() string {
+	return fmt.Sprintf("invalid private key: %s", i.reason)
+}
+
+// -- Malformed Signature --
+
+// NewMalformedSignatureError returns a new MalformedSignatureError provided
+// the length {int} of the rejected signature and the expected length {int}
+func NewMalformedSignatureError(length, expected int) MalformedSignatureError {
+	return MalformedSignatureError{length, expected}
+}
+
+// MalformedSignatureError is the error for a signature that cannot be decoded
+type MalformedSignatureError struct {
+	length   int
+	expected int
+}
+
+// Error returns the error {string} for a MalformedSignatureError
+func (m MalformedSignatureError) Error() string {
+	return fmt.Sprintf("malformed signature: got %d bytes; expected %d", m.length, m.expected)
+}
+
+// -- Public Key Recovery --
+
+// NewPublicKeyRecoveryError returns a new PublicKeyRecoveryError provided
+// the reason {string} no public key could be recovered
+func NewPublicKeyRecoveryError(reason string) PublicKeyRecoveryError {
+	return PublicKeyRecoveryError{reason}
+}
+
+// PublicKeyRecoveryError is the error for a recoverable signature that
+// does not yield a public key
+type PublicKeyRecoveryError struct {
+	reason string
+}
+
+// Error returns the error {string} for a PublicKeyRecoveryError
+func (p PublicKeyRecoveryError) Error() string {
+	return fmt.Sprintf("unable to recover public key: %s", p.reason)
+}
+
+// -- Signing --
+
+// NewSigningError returns a new SigningError provided
+// the error from the signing library
+func NewSigningError(err error) SigningError {
+	return SigningError{err: err.Error()}
+}
+
+// SigningError is the error for when a message fails to be signed
+type SigningError struct {
+	err string
+}
+
+// Error returns the error {string} for a SigningError
+func (s SigningError) Error() string {
+	return fmt.Sprintf("signing error: %s", s.err)
+}
+
+// -- PEM Decoding --
+
+// NewPemDecodingError returns a new PemDecodingError provided
+// the reason {string} the PEM could not be decoded
+func NewPemDecodingError(reason string) PemDecodingError {
+	return PemDecodingError{reason}
+}
+
+// PemDecodingError is the error for a PEM encoded key that cannot be decoded
+type PemDecodingError struct {
+	reason string
+}
+
+// Error returns the error {string} for a PemDecodingError
+func (p PemDecodingError) Error() string {
+	return fmt.Sprintf("failed to decode PEM key: %s", p.reason)
+}
+
+// NewPemEncodingError returns a new PemEncodingError provided
+// the error from the encoding library
+func NewPemEncodingError(err error) PemEncodingError {
+	return PemEncodingError{err: err.Error()}
+}
+
+// PemEncodingError is the error for a key that cannot be PEM encoded
+type PemEncodingError struct {
+	err string
+}
+
+// Error returns the error {string} for a PemEncodingError
+func (p PemEncodingError) Error() string {
+	return fmt.Sprintf("failed to encode PEM key: %s", p.err)
+}
+
+// -- Keystore --
+
+// NewKeyNotFoundError returns a new KeyNotFoundError provided
+// the key name {string}
+func NewKeyNotFoundError(name string) KeyNotFoundError {
+	return KeyNotFoundError{name}
+}
+
+// KeyNotFoundError is the error for a named key missing from a keystore
+type KeyNotFoundError struct {
+	name string
+}
+
+// Error returns the error {string} for a KeyNotFoundError
+func (k KeyNotFoundError) Error() string {
+	return fmt.Sprintf("key not found: %s", k.name)
+}
+
+// NewKeyExistsError returns a new KeyExistsError provided
+// the key name {string}
+func NewKeyExistsError(name string) KeyExistsError {
+	return KeyExistsError{name}
+}
+
+// KeyExistsError is the error for saving a key under a name already in use
+type KeyExistsError struct {
+	name string
+}
+
+// Error returns the error {string} for a KeyExistsError
+func (k KeyExistsError) Error() string {
+	return fmt.Sprintf("key already exists: %s", k.name)
+}
+
+// NewInvalidKeyNameError returns a new InvalidKeyNameError provided
+// the key name {string}
+func NewInvalidKeyNameError(name string) InvalidKeyNameError {
+	return InvalidKeyNameError{name}
+}
+
+// InvalidKeyNameError is the error for a key name that cannot be used as a
+// file name
+type InvalidKeyNameError struct {
+	name string
+}
+
+// Error returns the error {string} for an InvalidKeyNameError
+func (i InvalidKeyNameError) Error() string {
+	return fmt.Sprintf("invalid key name: %q; expected letters, digits, '.', '_' or '-'", i.name)
+}
+
+// NewDecryptionError returns a new DecryptionError provided
+// the key name {string}
+func NewDecryptionError(name string) DecryptionError {
+	return DecryptionError{name}
+}
+
+// DecryptionError is the error for a stored key that cannot be decrypted,
+// usually because the passphrase is wrong
+type DecryptionError struct {
+	name string
+}
+
+// Error returns the error {string} for a DecryptionError
+func (d DecryptionError) Error() string {
+	return fmt.Sprintf("failed to decrypt key %s: wrong passphrase or corrupt key file", d.name)
+}
+
+// -- REST Client --
+
+// NewUnexpectedStatusCodeError returns a new UnexpectedStatusCodeError
+// provided the HTTP status code {int} and the response body {string}
+func NewUnexpectedStatusCodeError(statusCode int, body string) UnexpectedStatusCodeError {
+	return UnexpectedStatusCodeError{statusCode, body}
+}
+
+// UnexpectedStatusCodeError is the error for a REST API response with a
+// status code the client does not handle
+type UnexpectedStatusCodeError struct {
+	statusCode int
+	body       string
+}
+
+// StatusCode returns the HTTP status code of the response
+func (u UnexpectedStatusCodeError) StatusCode() int {
+	return u.statusCode
+}
+
+// Error returns the error {string} for an UnexpectedStatusCodeError
+func (u UnexpectedStatusCodeError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", u.statusCode, u.body)
+}
+
+// NewInvalidTransactionError returns a new InvalidTransactionError provided
+// the batch ID {string}, the invalid transaction's ID {string}, and the
+// reason {string} it was rejected
+func NewInvalidTransactionError(batchID, txnID, message string) InvalidTransactionError {
+	return InvalidTransactionError{batchID, txnID, message}
+}
+
+// InvalidTransactionError is the error for a submitted batch that was
+// rejected because one of its transactions is invalid
+type InvalidTransactionError struct {
+	batchID string
+	txnID   string
+	message string
+}
+
+// BatchID returns the ID of the rejected batch
+func (i InvalidTransactionError) BatchID() string {
+	return i.batchID
+}
+
+// TransactionID returns the ID of the invalid transaction
+func (i InvalidTransactionError) TransactionID() string {
+	return i.txnID
+}
+
+// Message returns the reason the transaction was rejected
+func (i InvalidTransactionError) Message() string {
+	return i.message
+}
+
+// Error returns the error {string} for an InvalidTransactionError
+func (i InvalidTransactionError) Error() string {
+	return fmt.Sprintf("batch %s has invalid transaction %s: %s", i.batchID, i.txnID, i.message)
+}
+
+// NewStatusTimeoutError returns a new StatusTimeoutError provided
+// the IDs {[]string} of the batches that had not completed
+func NewStatusTimeoutError(batchIDs []string) StatusTimeoutError {
+	return StatusTimeoutError{batchIDs}
+}
+
+// StatusTimeoutError is the error for batches that were neither committed
+// nor rejected before the client stopped waiting
+type StatusTimeoutError struct {
+	batchIDs []string
+}
+
+// BatchIDs returns the IDs of the batches that had not completed
+func (s StatusTimeoutError) BatchIDs() []string {
+	return s.batchIDs
+}
+
+// Error returns the error {string} for a StatusTimeoutError
+func (s StatusTimeoutError) Error() string {
+	return fmt.Sprintf("timed out waiting for batches: %v", s.batchIDs)
+}
+
+// NewStateNotFoundError returns a new StateNotFoundError provided
+// the address {string} that has no value
+func NewStateNotFoundError(address string) StateNotFoundError {
+	return StateNotFoundError{address}
+}
+
+// StateNotFoundError is the error for a state address that has no value
+type StateNotFoundError struct {
+	address string
+}
+
+// Address returns the address that has no value
+func (s StateNotFoundError) Address() string {
+	return s.address
+}
+
+// Error returns the error {string} for a StateNotFoundError
+func (s StateNotFoundError) Error() string {
+	return fmt.Sprintf("no state at address: %s", s.address)
+}
+
+// -- Unknown Backend --
+
+// NewUnknownBackendError returns a new UnknownBackendError provided
+// the backend name {string}
+func NewUnknownBackendError(name string) UnknownBackendError {
+	return UnknownBackendError{name}
+}
+
+// UnknownBackendError is the error for a submitter backend that does not exist
+type UnknownBackendError struct {
+	name string
+}
+
+// Error returns the error {string} for an UnknownBackendError
+func (u UnknownBackendError) Error() string {
+	return fmt.Sprintf("unknown backend: %s", u.name)
+}
+
+// -- Invalid Retry Policy --
+
+// NewInvalidRetryPolicyError returns a new InvalidRetryPolicyError provided
+// the reason {string} the policy was rejected
+func NewInvalidRetryPolicyError(reason string) InvalidRetryPolicyError {
+	return InvalidRetryPolicyError{reason}
+}
+
+// InvalidRetryPolicyError is the error for retry options that cannot be used
+type InvalidRetryPolicyError struct {
+	reason string
+}
+
+// Error returns the error {string} for an InvalidRetryPolicyError
+func (i InvalidRetryPolicyError) Error() string {
+	return fmt.Sprintf("invalid retry policy: %s", i.reason)
+}
+
+// -- Invalid Scrypt Parameters --
+
+// NewInvalidScryptParametersError returns a new InvalidScryptParametersError
+// provided the scrypt cost parameters N, r and p {int}
+func NewInvalidScryptParametersError(n, r, p int) InvalidScryptParametersError {
+	return InvalidScryptParametersError{n, r, p}
+}
+
+// InvalidScryptParametersError is the error for scrypt cost parameters that
+// are malformed or too expensive to use
+type InvalidScryptParametersError struct {
+	n int
+	r int
+	p int
+}
+
+// Error returns the error {string} for an InvalidScryptParametersError
+func (i InvalidScryptParametersError) Error() string {
+	return fmt.Sprintf("invalid scrypt parameters: N=%d, r=%d, p=%d", i.n, i.r, i.p)
+}
@@ -7,5 +7,6 @@ require (
 	github.com/golang/protobuf v1.4.0
 	github.com/hyperledger/sawtooth-sdk-go v0.1.3
 	github.com/txross/transact-sdk-go v0.0.0-20200421192921-dc74b0bcd22d
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 	google.golang.org/protobuf v1.21.0
 )
@@ -0,0 +1,129 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package restclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+)
+
+// IStatuses is a list of batch statuses as reported by a REST API
+type IStatuses interface {
+	Len() int
+	ID(i int) string
+	IsCommitted(i int) bool
+	IsInvalid(i int) bool
+	// Returns the first rejected transaction of an invalid batch, if the
+	// REST API reported one.
+	InvalidTransaction(i int) (id string, message string, ok bool)
+}
+
+// Poller polls batch statuses until the batches complete
+type Poller struct {
+	// How long to wait between polls.
+	Interval time.Duration
+
+	// How long the REST API may hold each poll open.
+	StatusWait time.Duration
+}
+
+// WaitForBatches calls getStatuses with the wait for each poll until every
+// one of ids is committed, returning the last statuses. Returns an
+// InvalidTransactionError as soon as a batch is rejected, and a
+// StatusTimeoutError if the batches have not completed within timeout.
+// A timeout of zero waits until ctx is done.
+func (p Poller) WaitForBatches(ctx context.Context, ids []string, timeout time.Duration, getStatuses func(wait time.Duration) (IStatuses, error)) error {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	for {
+		wait := p.StatusWait
+		if !deadline.IsZero() {
+			if remaining := time.Until(deadline); remaining < wait {
+				wait = remaining
+			}
+		}
+
+		statuses, err := getStatuses(wait)
+		if err != nil {
+			return err
+		}
+
+		if err := InvalidBatchError(statuses); err != nil {
+			return err
+		}
+		if statuses.Len() == len(ids) && AllCommitted(statuses) {
+			return nil
+		}
+
+		if !deadline.IsZero() && !time.Now().Before(deadline) {
+			return errors.NewStatusTimeoutError(PendingIDs(ids, statuses))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(p.Interval):
+		}
+	}
+}
+
+// InvalidBatchError returns an InvalidTransactionError for the first
+// invalid batch, or nil if no batch is invalid
+func InvalidBatchError(statuses IStatuses) error {
+	for i := 0; i < statuses.Len(); i++ {
+		if !statuses.IsInvalid(i) {
+			continue
+		}
+		if txnID, message, ok := statuses.InvalidTransaction(i); ok {
+			return errors.NewInvalidTransactionError(statuses.ID(i), txnID, message)
+		}
+		return errors.NewInvalidTransactionError(statuses.ID(i), "", "batch is invalid")
+	}
+	return nil
+}
+
+// AllCommitted returns whether every batch is committed
+func AllCommitted(statuses IStatuses) bool {
+	for i := 0; i < statuses.Len(); i++ {
+		if !statuses.IsCommitted(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// PendingIDs returns the IDs of the batches that are not committed
+func PendingIDs(ids []string, statuses IStatuses) []string {
+	committed := make(map[string]bool, statuses.Len())
+	for i := 0; i < statuses.Len(); i++ {
+		if statuses.IsCommitted(i) {
+			committed[statuses.ID(i)] = true
+		}
+	}
+
+	var pending []string
+	for _, id := range ids {
+		if !committed[id] {
+			pending = append(pending, id)
+		}
+	}
+	return pending
+}
@@ -0,0 +1,60 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+// Package restclient holds the HTTP and batch status polling logic shared
+// by the Scabbard and Sawtooth REST API clients.
+package restclient
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+)
+
+// Do sends the request with ctx and decodes its JSON response into result.
+// A response with a status code other than expected is returned as an
+// UnexpectedStatusCodeError, whose message errorMessage extracts from the
+// response body. A nil errorMessage uses the body itself.
+func Do(ctx context.Context, client *http.Client, req *http.Request, expected int, result interface{}, errorMessage func(body []byte) string) error {
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != expected {
+		if errorMessage == nil {
+			errorMessage = trimmedBody
+		}
+		return errors.NewUnexpectedStatusCodeError(resp.StatusCode, errorMessage(body))
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return err
+	}
+	return nil
+}
+
+func trimmedBody(body []byte) string {
+	return strings.TrimSpace(string(body))
+}
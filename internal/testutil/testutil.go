@@ -0,0 +1,59 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+// Package testutil holds fixtures shared by the client and submitter tests.
+package testutil
+
+import (
+	"testing"
+
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transactions"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+// BuildBatch returns a batch of one intkey transaction with the given
+// payload, signed by a new random key
+func BuildBatch(tb testing.TB, payload string) *transaction_pb2.Batch {
+	context := signing.NewSecp256k1Context()
+	signer := signing.NewCryptoFactory(context).NewSigner(context.NewRandomPrivateKey())
+
+	txnBuilder, err := transactions.NewTransactionBuilder(
+		transactions.WithFamilyName("intkey"),
+		transactions.WithFamilyVersion("1.0"),
+		transactions.WithInputs([]string{"1cf126"}),
+		transactions.WithOutputs([]string{"1cf126"}),
+		transactions.WithPayload([]byte(payload)),
+	)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	txn, err := txnBuilder.Build(signer)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	batchBuilder, err := transactions.NewBatchBuilder(
+		transactions.WithTransactions([]*transaction_pb2.Transaction{txn}),
+	)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	batch, err := batchBuilder.BuildBatch(signer)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return batch
+}
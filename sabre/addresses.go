@@ -0,0 +1,162 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package sabre
+
+import (
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/sabre/addressing"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/sabre_pb2"
+)
+
+// RequiredAddresses returns the input and output addresses the Sabre
+// transaction processor checks for the payload's action, including any
+// state addresses supplied in the payload itself. The signer's public key
+// is only used by the smart permission actions, which look up the signer's
+// pike agent.
+func RequiredAddresses(payload *sabre_pb2.SabrePayload,
+	signerPublicKey string) (inputs []string, outputs []string, err error) {
+	if payload == nil {
+		return nil, nil, errors.NewMissingFieldError("payload")
+	}
+
+	switch payload.GetAction() {
+	case sabre_pb2.SabrePayload_CREATE_CONTRACT:
+		action := payload.GetCreateContract()
+		return prepareContractAddresses(action.GetName(), action.GetVersion())
+	case sabre_pb2.SabrePayload_DELETE_CONTRACT:
+		action := payload.GetDeleteContract()
+		return prepareContractAddresses(action.GetName(), action.GetVersion())
+	case sabre_pb2.SabrePayload_EXECUTE_CONTRACT:
+		action := payload.GetExecuteContract()
+		return prepareExecuteContractAddresses(action.GetName(), action.GetVersion(),
+			action.GetInputs(), action.GetOutputs())
+	case sabre_pb2.SabrePayload_CREATE_CONTRACT_REGISTRY:
+		return prepareContractRegistryAddresses(payload.GetCreateContractRegistry().GetName())
+	case sabre_pb2.SabrePayload_DELETE_CONTRACT_REGISTRY:
+		return prepareContractRegistryAddresses(payload.GetDeleteContractRegistry().GetName())
+	case sabre_pb2.SabrePayload_UPDATE_CONTRACT_REGISTRY_OWNERS:
+		return prepareContractRegistryAddresses(payload.GetUpdateContractRegistryOwners().GetName())
+	case sabre_pb2.SabrePayload_CREATE_NAMESPACE_REGISTRY:
+		return prepareNamespaceRegistryAddresses(payload.GetCreateNamespaceRegistry().GetNamespace())
+	case sabre_pb2.SabrePayload_DELETE_NAMESPACE_REGISTRY:
+		return prepareNamespaceRegistryAddresses(payload.GetDeleteNamespaceRegistry().GetNamespace())
+	case sabre_pb2.SabrePayload_UPDATE_NAMESPACE_REGISTRY_OWNERS:
+		return prepareNamespaceRegistryAddresses(payload.GetUpdateNamespaceRegistryOwners().GetNamespace())
+	case sabre_pb2.SabrePayload_CREATE_NAMESPACE_REGISTRY_PERMISSION:
+		return prepareNamespaceRegistryAddresses(payload.GetCreateNamespaceRegistryPermission().GetNamespace())
+	case sabre_pb2.SabrePayload_DELETE_NAMESPACE_REGISTRY_PERMISSION:
+		return prepareNamespaceRegistryAddresses(payload.GetDeleteNamespaceRegistryPermission().GetNamespace())
+	case sabre_pb2.SabrePayload_CREATE_SMART_PERMISSION:
+		action := payload.GetCreateSmartPermission()
+		return prepareSmartPermissionAddresses(action.GetOrgId(), action.GetName(), signerPublicKey)
+	case sabre_pb2.SabrePayload_UPDATE_SMART_PERMISSION:
+		action := payload.GetUpdateSmartPermission()
+		return prepareSmartPermissionAddresses(action.GetOrgId(), action.GetName(), signerPublicKey)
+	case sabre_pb2.SabrePayload_DELETE_SMART_PERMISSION:
+		action := payload.GetDeleteSmartPermission()
+		return prepareSmartPermissionAddresses(action.GetOrgId(), action.GetName(), signerPublicKey)
+	}
+
+	return nil, nil, errors.NewInvalidActionError(payload.GetAction().String(), sabreActionNames())
+}
+
+// prepareContractAddresses returns the addresses for creating or deleting
+// a version of a contract, which reads and writes both the contract and
+// its registry
+func prepareContractAddresses(name, version string) ([]string, []string, error) {
+	addresses := []string{
+		addressing.ComputeContractRegistryAddress(name),
+		addressing.ComputeContractAddress(name, version),
+	}
+	return addresses, addresses, nil
+}
+
+// prepareExecuteContractAddresses returns the addresses for executing a
+// contract. The processor reads the contract, its registry and the
+// namespace registry of every address the contract may touch, while
+// writes are limited to the contract's own outputs.
+func prepareExecuteContractAddresses(name, version string,
+	contractInputs, contractOutputs []string) ([]string, []string, error) {
+	inputs := []string{
+		addressing.ComputeContractRegistryAddress(name),
+		addressing.ComputeContractAddress(name, version),
+	}
+
+	for _, address := range mergeAddresses(contractInputs, contractOutputs) {
+		if len(address) < 6 {
+			return nil, nil, errors.NewInvalidAddressError(address)
+		}
+		inputs = append(inputs, addressing.CalculateNamespaceRegistryAddress(address))
+	}
+
+	return mergeAddresses(inputs, contractInputs), mergeAddresses(contractOutputs), nil
+}
+
+// prepareContractRegistryAddresses returns the addresses for the contract
+// registry actions, which also check the administrators setting
+func prepareContractRegistryAddresses(name string) ([]string, []string, error) {
+	registry := addressing.ComputeContractRegistryAddress(name)
+	return []string{registry, addressing.AdministratorsSettingAddress}, []string{registry}, nil
+}
+
+// prepareNamespaceRegistryAddresses returns the addresses for the namespace
+// registry and namespace registry permission actions, which also check the
+// administrators setting
+func prepareNamespaceRegistryAddresses(namespace string) ([]string, []string, error) {
+	if len(namespace) < 6 {
+		return nil, nil, errors.NewInvalidAddressError(namespace)
+	}
+	registry := addressing.CalculateNamespaceRegistryAddress(namespace)
+	return []string{registry, addressing.AdministratorsSettingAddress}, []string{registry}, nil
+}
+
+// prepareSmartPermissionAddresses returns the addresses for the smart
+// permission actions, which check that the signer is an admin of the owning
+// pike organization
+func prepareSmartPermissionAddresses(orgID, name, signerPublicKey string) ([]string, []string, error) {
+	smartPermission := addressing.CalculateSmartPermissionAddress(orgID, name)
+	inputs := []string{
+		smartPermission,
+		addressing.ComputePikeOrganizationAddress(orgID),
+		addressing.ComputePikeAgentAddress(signerPublicKey),
+	}
+	return inputs, []string{smartPermission}, nil
+}
+
+// mergeAddresses concatenates the address lists, dropping duplicates while
+// preserving the order in which addresses first appear
+func mergeAddresses(lists ...[]string) []string {
+	seen := make(map[string]bool)
+	merged := []string{}
+	for _, list := range lists {
+		for _, address := range list {
+			if seen[address] {
+				continue
+			}
+			seen[address] = true
+			merged = append(merged, address)
+		}
+	}
+	return merged
+}
+
+func sabreActionNames() []string {
+	names := make([]string, 0, len(sabre_pb2.SabrePayload_Action_name)-1)
+	for i := int32(1); i < int32(len(sabre_pb2.SabrePayload_Action_name)); i++ {
+		names = append(names, sabre_pb2.SabrePayload_Action_name[i])
+	}
+	return names
+}
@@ -34,6 +34,16 @@ const (
 
 	// PikePrefix is the global address for pike
 	PikePrefix = "cad11d"
+
+	// PikeAgentPrefix is the pike agent prefix for global state
+	PikeAgentPrefix = "cad11d00"
+
+	// PikeOrganizationPrefix is the pike organization prefix for global state
+	PikeOrganizationPrefix = "cad11d01"
+
+	// AdministratorsSettingAddress is the state address of the
+	// sawtooth.swa.administrators setting
+	AdministratorsSettingAddress = "000000a87cb5eafdcca6a814e4add97c4b517d3c530c2f44b31d18e3b0c44298fc1c14"
 )
 
 // CalculateNamespaceRegistryAddress calculates the registry address for a namespace.
@@ -77,14 +87,30 @@ func CalculateDeploymentAddress(contractName, deploymentName string) string {
 	return concat(ComputeContractPrefix(contractName), deploymentAddress)
 }
 
-// CalculateSmartPermissionAddress calculate sthe state address for a given
+// CalculateSmartPermissionAddress calculates the state address for a given
 // smart permission
 func CalculateSmartPermissionAddress(organizationID, permissionName string) string {
-	orgIDPrefix := crypto.NewSha512Hash([]byte(organizationID)[:6])
-	smartPermission := crypto.NewSha512Hash([]byte(permissionName)[:58])
+	orgIDPrefix := crypto.NewSha512Hash([]byte(organizationID))[:6]
+	smartPermission := crypto.NewSha512Hash([]byte(permissionName))[:58]
 	return concat(SmartPermissionPrefix, orgIDPrefix, smartPermission)
 }
 
+// ComputePikeAgentAddress calculates the pike agent address for a
+// hex-encoded public key {string}
+// Returns the Agent Address {string}
+func ComputePikeAgentAddress(publicKey string) string {
+	hash := crypto.NewSha512Hash([]byte(publicKey))[:62]
+	return concat(PikeAgentPrefix, hash)
+}
+
+// ComputePikeOrganizationAddress calculates the pike organization address
+// for an organization id {string}
+// Returns the Organization Address {string}
+func ComputePikeOrganizationAddress(organizationID string) string {
+	hash := crypto.NewSha512Hash([]byte(organizationID))[:62]
+	return concat(PikeOrganizationPrefix, hash)
+}
+
 // concat uses a preallocated copy method for efficient string concatenation
 func concat(s1 string, additional ...string) string {
 	bl := 0
@@ -0,0 +1,233 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger/transact-sdk-go/sabre"
+	"github.com/hyperledger/transact-sdk-go/sabre/addressing"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/sabre_pb2"
+)
+
+const (
+	SIGNER    = "035e1de3048a62f9f478440a22fd7655b80f0aac997be963b119ac54b3bfdea3b7"
+	GAME      = "5b7349c6ee9e33cf5c6715a1d148fd73f7318884b41adcb916021e2bc0e800a5c5dd97"
+	REGISTRY  = "00ec015b73490bd3e0c08a4e4f816d3426ddfbbb7ebbf156b6b2dad30133f75acd96cf"
+	CONTRACT  = "00ec02eb100877027434fb7f0d9cd6a77c707263d76c49c237cbfa937fd4bc7ea20f34"
+	NAMESPACE = "00ec00b5f9357d36d427b067ab839a36550c767bc9c1ddf573d2540f9fd9ad4ea28591"
+	SMARTPERM = "00ec03c13476f2e0ff66bd697cb4953cc21b601ffbd925f4f81aa687b1a5918a3c25d9"
+	PIKEORG   = "cad11d01c1347621114982d2df682218c4d87a37d133f415b4f09681752b701f18b425"
+	PIKEAGENT = "cad11d0030082769290bd88844725471bfaaf8f462daf37f9642b7113123e768d26b61"
+	ADMINS    = "000000a87cb5eafdcca6a814e4add97c4b517d3c530c2f44b31d18e3b0c44298fc1c14"
+)
+
+func TestAddressingVectors(t *testing.T) {
+	vectors := []struct {
+		name     string
+		actual   string
+		expected string
+	}{
+		{"deployment", addressing.CalculateDeploymentAddress("xo", "Test"), GAME},
+		{"contract registry", addressing.ComputeContractRegistryAddress("xo"), REGISTRY},
+		{"contract", addressing.ComputeContractAddress("xo", "0.3.3"), CONTRACT},
+		{"namespace registry", addressing.CalculateNamespaceRegistryAddress("5b7349"), NAMESPACE},
+		{"smart permission", addressing.CalculateSmartPermissionAddress("acme", "perm"), SMARTPERM},
+		{"pike organization", addressing.ComputePikeOrganizationAddress("acme"), PIKEORG},
+		{"pike agent", addressing.ComputePikeAgentAddress(SIGNER), PIKEAGENT},
+	}
+
+	for _, v := range vectors {
+		if v.actual != v.expected {
+			t.Errorf("%s address: expected %s, got %s", v.name, v.expected, v.actual)
+		}
+	}
+}
+
+func TestRequiredAddresses(t *testing.T) {
+	vectors := []struct {
+		payload *sabre_pb2.SabrePayload
+		inputs  []string
+		outputs []string
+	}{
+		{
+			&sabre_pb2.SabrePayload{
+				Action: sabre_pb2.SabrePayload_CREATE_CONTRACT,
+				CreateContract: &sabre_pb2.CreateContractAction{
+					Name: "xo", Version: "0.3.3", Contract: []byte{0x00},
+				},
+			},
+			[]string{REGISTRY, CONTRACT},
+			[]string{REGISTRY, CONTRACT},
+		},
+		{
+			&sabre_pb2.SabrePayload{
+				Action:         sabre_pb2.SabrePayload_DELETE_CONTRACT,
+				DeleteContract: &sabre_pb2.DeleteContractAction{Name: "xo", Version: "0.3.3"},
+			},
+			[]string{REGISTRY, CONTRACT},
+			[]string{REGISTRY, CONTRACT},
+		},
+		{
+			&sabre_pb2.SabrePayload{
+				Action: sabre_pb2.SabrePayload_EXECUTE_CONTRACT,
+				ExecuteContract: &sabre_pb2.ExecuteContractAction{
+					Name:    "xo",
+					Version: "0.3.3",
+					Inputs:  []string{GAME},
+					Outputs: []string{GAME},
+					Payload: []byte("Test,take,3"),
+				},
+			},
+			[]string{REGISTRY, CONTRACT, NAMESPACE, GAME},
+			[]string{GAME},
+		},
+		{
+			&sabre_pb2.SabrePayload{
+				Action:                 sabre_pb2.SabrePayload_CREATE_CONTRACT_REGISTRY,
+				CreateContractRegistry: &sabre_pb2.CreateContractRegistryAction{Name: "xo", Owners: []string{SIGNER}},
+			},
+			[]string{REGISTRY, ADMINS},
+			[]string{REGISTRY},
+		},
+		{
+			&sabre_pb2.SabrePayload{
+				Action:                 sabre_pb2.SabrePayload_DELETE_CONTRACT_REGISTRY,
+				DeleteContractRegistry: &sabre_pb2.DeleteContractRegistryAction{Name: "xo"},
+			},
+			[]string{REGISTRY, ADMINS},
+			[]string{REGISTRY},
+		},
+		{
+			&sabre_pb2.SabrePayload{
+				Action:                       sabre_pb2.SabrePayload_UPDATE_CONTRACT_REGISTRY_OWNERS,
+				UpdateContractRegistryOwners: &sabre_pb2.UpdateContractRegistryOwnersAction{Name: "xo", Owners: []string{SIGNER}},
+			},
+			[]string{REGISTRY, ADMINS},
+			[]string{REGISTRY},
+		},
+		{
+			&sabre_pb2.SabrePayload{
+				Action:                  sabre_pb2.SabrePayload_CREATE_NAMESPACE_REGISTRY,
+				CreateNamespaceRegistry: &sabre_pb2.CreateNamespaceRegistryAction{Namespace: "5b7349", Owners: []string{SIGNER}},
+			},
+			[]string{NAMESPACE, ADMINS},
+			[]string{NAMESPACE},
+		},
+		{
+			&sabre_pb2.SabrePayload{
+				Action:                  sabre_pb2.SabrePayload_DELETE_NAMESPACE_REGISTRY,
+				DeleteNamespaceRegistry: &sabre_pb2.DeleteNamespaceRegistryAction{Namespace: "5b7349"},
+			},
+			[]string{NAMESPACE, ADMINS},
+			[]string{NAMESPACE},
+		},
+		{
+			&sabre_pb2.SabrePayload{
+				Action:                        sabre_pb2.SabrePayload_UPDATE_NAMESPACE_REGISTRY_OWNERS,
+				UpdateNamespaceRegistryOwners: &sabre_pb2.UpdateNamespaceRegistryOwnersAction{Namespace: "5b7349", Owners: []string{SIGNER}},
+			},
+			[]string{NAMESPACE, ADMINS},
+			[]string{NAMESPACE},
+		},
+		{
+			&sabre_pb2.SabrePayload{
+				Action: sabre_pb2.SabrePayload_CREATE_NAMESPACE_REGISTRY_PERMISSION,
+				CreateNamespaceRegistryPermission: &sabre_pb2.CreateNamespaceRegistryPermissionAction{
+					Namespace: "5b7349", ContractName: "xo", Read: true, Write: true,
+				},
+			},
+			[]string{NAMESPACE, ADMINS},
+			[]string{NAMESPACE},
+		},
+		{
+			&sabre_pb2.SabrePayload{
+				Action: sabre_pb2.SabrePayload_DELETE_NAMESPACE_REGISTRY_PERMISSION,
+				DeleteNamespaceRegistryPermission: &sabre_pb2.DeleteNamespaceRegistryPermissionAction{
+					Namespace: "5b7349", ContractName: "xo",
+				},
+			},
+			[]string{NAMESPACE, ADMINS},
+			[]string{NAMESPACE},
+		},
+		{
+			&sabre_pb2.SabrePayload{
+				Action: sabre_pb2.SabrePayload_CREATE_SMART_PERMISSION,
+				CreateSmartPermission: &sabre_pb2.CreateSmartPermissionAction{
+					Name: "perm", OrgId: "acme", Function: []byte{0x00},
+				},
+			},
+			[]string{SMARTPERM, PIKEORG, PIKEAGENT},
+			[]string{SMARTPERM},
+		},
+		{
+			&sabre_pb2.SabrePayload{
+				Action: sabre_pb2.SabrePayload_UPDATE_SMART_PERMISSION,
+				UpdateSmartPermission: &sabre_pb2.UpdateSmartPermissionAction{
+					Name: "perm", OrgId: "acme", Function: []byte{0x00},
+				},
+			},
+			[]string{SMARTPERM, PIKEORG, PIKEAGENT},
+			[]string{SMARTPERM},
+		},
+		{
+			&sabre_pb2.SabrePayload{
+				Action:                sabre_pb2.SabrePayload_DELETE_SMART_PERMISSION,
+				DeleteSmartPermission: &sabre_pb2.DeleteSmartPermissionAction{Name: "perm", OrgId: "acme"},
+			},
+			[]string{SMARTPERM, PIKEORG, PIKEAGENT},
+			[]string{SMARTPERM},
+		},
+	}
+
+	for _, v := range vectors {
+		inputs, outputs, err := sabre.RequiredAddresses(v.payload, SIGNER)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", v.payload.GetAction(), err)
+			continue
+		}
+		if !reflect.DeepEqual(inputs, v.inputs) {
+			t.Errorf("%s: expected inputs %v, got %v", v.payload.GetAction(), v.inputs, inputs)
+		}
+		if !reflect.DeepEqual(outputs, v.outputs) {
+			t.Errorf("%s: expected outputs %v, got %v", v.payload.GetAction(), v.outputs, outputs)
+		}
+	}
+}
+
+func TestRequiredAddressesErrors(t *testing.T) {
+	payloads := []*sabre_pb2.SabrePayload{
+		nil,
+		{Action: sabre_pb2.SabrePayload_ACTION_UNSET},
+		{
+			Action: sabre_pb2.SabrePayload_EXECUTE_CONTRACT,
+			ExecuteContract: &sabre_pb2.ExecuteContractAction{
+				Name: "xo", Version: "0.3.3", Inputs: []string{"5b73"}, Payload: []byte{0x00},
+			},
+		},
+		{
+			Action:                  sabre_pb2.SabrePayload_DELETE_NAMESPACE_REGISTRY,
+			DeleteNamespaceRegistry: &sabre_pb2.DeleteNamespaceRegistryAction{Namespace: "5b"},
+		},
+	}
+
+	for _, payload := range payloads {
+		if _, _, err := sabre.RequiredAddresses(payload, SIGNER); err == nil {
+			t.Errorf("%s: expected an error", payload.GetAction())
+		}
+	}
+}
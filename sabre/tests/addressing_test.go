@@ -0,0 +1,69 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/transact-sdk-go/sabre/addressing"
+)
+
+func TestAddressFormat(t *testing.T) {
+	addresses := []struct {
+		address string
+		prefix  string
+	}{
+		{addressing.CalculateNamespaceRegistryAddress("5b7349"), addressing.NamespaceRegistryPrefix},
+		{addressing.ComputeContractAddress("xo", "0.3.3"), addressing.ContractPrefix},
+		{addressing.ComputeContractRegistryAddress("xo"), addressing.ContractRegistryPrefix},
+		{addressing.CalculateDeploymentAddress("xo", "Test"), addressing.ComputeContractPrefix("xo")},
+		{addressing.CalculateSmartPermissionAddress("acme", "perm"), addressing.SmartPermissionPrefix},
+		{addressing.ComputePikeAgentAddress(SIGNER), addressing.PikeAgentPrefix},
+		{addressing.ComputePikeOrganizationAddress("acme"), addressing.PikeOrganizationPrefix},
+	}
+
+	for _, a := range addresses {
+		if len(a.address) != 70 {
+			t.Errorf("%s: expected 70 characters, got %d", a.address, len(a.address))
+		}
+		if !strings.HasPrefix(a.address, a.prefix) {
+			t.Errorf("%s: expected prefix %s", a.address, a.prefix)
+		}
+		if _, err := hex.DecodeString(a.address); err != nil {
+			t.Errorf("%s: %v", a.address, err)
+		}
+	}
+}
+
+func TestSmartPermissionAddressParts(t *testing.T) {
+	// The organization's hash prefix sits between the smart permission
+	// prefix and the permission name's hash
+	first := addressing.CalculateSmartPermissionAddress("acme", "perm")
+	second := addressing.CalculateSmartPermissionAddress("acme", "other")
+	third := addressing.CalculateSmartPermissionAddress("globex", "perm")
+
+	if first[:12] != second[:12] {
+		t.Errorf("Expected permissions of one organization to share a prefix: %s, %s", first, second)
+	}
+	if first[12:] == second[12:] {
+		t.Errorf("Expected different permission names to differ: %s, %s", first, second)
+	}
+	if first[:12] == third[:12] || first[12:] != third[12:] {
+		t.Errorf("Expected only the organization part to differ: %s, %s", first, third)
+	}
+}
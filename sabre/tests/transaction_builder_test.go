@@ -0,0 +1,258 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger/transact-sdk-go/sabre"
+	"github.com/hyperledger/transact-sdk-go/sabre/addressing"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/sabre_pb2"
+	"github.com/hyperledger/transact-sdk-go/transactions"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+func TestSabreTransaction(t *testing.T) {
+	context := signing.NewSecp256k1Context()
+	signer := signing.NewCryptoFactory(context).NewSigner(context.NewRandomPrivateKey())
+
+	payloadBuilder, err := sabre.NewSabrePayloadBuilder(
+		sabre.WithAction(sabre_pb2.SabrePayload_EXECUTE_CONTRACT),
+		sabre.WithContractName("xo"),
+		sabre.WithContractVersion("0.3.3"),
+		sabre.WithInputs([]string{GAME}),
+		sabre.WithOutputs([]string{GAME}),
+		sabre.WithExecuteContractPayload([]byte("Test,take,3")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txnBuilder, err := transactions.NewTransactionBuilder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sabreBuilder, err := sabre.NewSabreTransactionBuilder(
+		sabre.WithPayloadBuilder(payloadBuilder),
+		sabre.WithTransactionBuilder(txnBuilder),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txn, err := sabreBuilder.Build(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := transactions.ParseTransaction(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsed.ID != txn.GetHeaderSignature() {
+		t.Error("Parsed transaction has wrong id")
+	}
+	if parsed.Header.GetFamilyName() != sabre.SabreFamilyName {
+		t.Error("Parsed transaction has wrong family name", parsed.Header.GetFamilyName())
+	}
+	if parsed.SabrePayload == nil {
+		t.Fatal("Parsed sabre transaction is missing its payload")
+	}
+	if string(parsed.SabrePayload.GetExecuteContract().GetPayload()) != "Test,take,3" {
+		t.Error("Parsed sabre payload has wrong contract payload")
+	}
+
+	expectedInputs := []string{REGISTRY, CONTRACT, NAMESPACE, GAME}
+	if len(parsed.Header.GetInputs()) != len(expectedInputs) {
+		t.Fatalf("Expected inputs %v, got %v", expectedInputs, parsed.Header.GetInputs())
+	}
+	for i, input := range expectedInputs {
+		if parsed.Header.GetInputs()[i] != input {
+			t.Errorf("Expected inputs %v, got %v", expectedInputs, parsed.Header.GetInputs())
+		}
+	}
+
+	if err := transactions.VerifyTransaction(context, txn); err != nil {
+		t.Error("Failed to verify sabre transaction", err)
+	}
+}
+
+func TestSabreTransactionAddresses(t *testing.T) {
+	context := signing.NewSecp256k1Context()
+	privateKey := context.NewRandomPrivateKey()
+	signer := signing.NewCryptoFactory(context).NewSigner(privateKey)
+	agent := addressing.ComputePikeAgentAddress(context.GetPublicKey(privateKey).AsHex())
+
+	vectors := []struct {
+		opts    []sabre.SabrePayloadOption
+		inputs  []string
+		outputs []string
+	}{
+		{
+			[]sabre.SabrePayloadOption{
+				sabre.WithAction(sabre_pb2.SabrePayload_CREATE_CONTRACT),
+				sabre.WithContractName("xo"),
+				sabre.WithContractVersion("0.3.3"),
+				sabre.WithInputs([]string{GAME}),
+				sabre.WithOutputs([]string{GAME}),
+				sabre.WithContract([]byte{0x00}),
+			},
+			[]string{REGISTRY, CONTRACT},
+			[]string{REGISTRY, CONTRACT},
+		},
+		{
+			[]sabre.SabrePayloadOption{
+				sabre.WithAction(sabre_pb2.SabrePayload_EXECUTE_CONTRACT),
+				sabre.WithContractName("xo"),
+				sabre.WithContractVersion("0.3.3"),
+				sabre.WithInputs([]string{GAME}),
+				sabre.WithOutputs([]string{GAME}),
+				sabre.WithExecuteContractPayload([]byte("Test,take,3")),
+			},
+			[]string{REGISTRY, CONTRACT, NAMESPACE, GAME},
+			[]string{GAME},
+		},
+		{
+			[]sabre.SabrePayloadOption{
+				sabre.WithAction(sabre_pb2.SabrePayload_CREATE_CONTRACT_REGISTRY),
+				sabre.WithContractName("xo"),
+				sabre.WithOwners([]string{SIGNER}),
+			},
+			[]string{REGISTRY, ADMINS},
+			[]string{REGISTRY},
+		},
+		{
+			[]sabre.SabrePayloadOption{
+				sabre.WithAction(sabre_pb2.SabrePayload_CREATE_NAMESPACE_REGISTRY),
+				sabre.WithNamespace("5b7349"),
+				sabre.WithOwners([]string{SIGNER}),
+			},
+			[]string{NAMESPACE, ADMINS},
+			[]string{NAMESPACE},
+		},
+		{
+			[]sabre.SabrePayloadOption{
+				sabre.WithAction(sabre_pb2.SabrePayload_CREATE_NAMESPACE_REGISTRY_PERMISSION),
+				sabre.WithNamespace("5b7349"),
+				sabre.WithContractName("xo"),
+				sabre.WithNamespaceReadPermission(true),
+				sabre.WithNamespaceWritePermission(true),
+			},
+			[]string{NAMESPACE, ADMINS},
+			[]string{NAMESPACE},
+		},
+		{
+			[]sabre.SabrePayloadOption{
+				sabre.WithAction(sabre_pb2.SabrePayload_CREATE_SMART_PERMISSION),
+				sabre.WithOrgID("acme"),
+				sabre.WithSmartPermissionName("perm"),
+				sabre.WithSmartPermissionFunction([]byte{0x00}),
+			},
+			[]string{SMARTPERM, PIKEORG, agent},
+			[]string{SMARTPERM},
+		},
+	}
+
+	for _, v := range vectors {
+		payloadBuilder, err := sabre.NewSabrePayloadBuilder(v.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txnBuilder, err := transactions.NewTransactionBuilder()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sabreBuilder, err := sabre.NewSabreTransactionBuilder(
+			sabre.WithPayloadBuilder(payloadBuilder),
+			sabre.WithTransactionBuilder(txnBuilder),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		txn, err := sabreBuilder.Build(signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := transactions.ParseTransaction(txn)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		action := parsed.SabrePayload.GetAction()
+		if !reflect.DeepEqual(parsed.Header.GetInputs(), v.inputs) {
+			t.Errorf("%s: expected inputs %v, got %v", action, v.inputs, parsed.Header.GetInputs())
+		}
+		if !reflect.DeepEqual(parsed.Header.GetOutputs(), v.outputs) {
+			t.Errorf("%s: expected outputs %v, got %v", action, v.outputs, parsed.Header.GetOutputs())
+		}
+	}
+}
+
+func TestSabreTransactionGraph(t *testing.T) {
+	context := signing.NewSecp256k1Context()
+	signer := signing.NewCryptoFactory(context).NewSigner(context.NewRandomPrivateKey())
+
+	graph := transactions.NewGraph()
+	for _, move := range []string{"Test,create,", "Test,take,3"} {
+		payloadBuilder, err := sabre.NewSabrePayloadBuilder(
+			sabre.WithAction(sabre_pb2.SabrePayload_EXECUTE_CONTRACT),
+			sabre.WithContractName("xo"),
+			sabre.WithContractVersion("0.3.3"),
+			sabre.WithInputs([]string{GAME}),
+			sabre.WithOutputs([]string{GAME}),
+			sabre.WithExecuteContractPayload([]byte(move)),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txnBuilder, err := transactions.NewTransactionBuilder()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sabreBuilder, err := sabre.NewSabreTransactionBuilder(
+			sabre.WithPayloadBuilder(payloadBuilder),
+			sabre.WithTransactionBuilder(txnBuilder),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := graph.AddNode(move, sabreBuilder); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := graph.AddDependency("Test,take,3", "Test,create,"); err != nil {
+		t.Fatal(err)
+	}
+
+	txns, err := graph.Build(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := transactions.ParseTransaction(txns[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	deps := parsed.Header.GetDependencies()
+	if len(deps) != 1 || deps[0] != txns[0].GetHeaderSignature() {
+		t.Errorf("Expected the move to depend on the create, got %v", deps)
+	}
+	if string(parsed.SabrePayload.GetExecuteContract().GetPayload()) != "Test,take,3" {
+		t.Error("Graph built transactions out of order")
+	}
+}
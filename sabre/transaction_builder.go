@@ -22,7 +22,6 @@ import (
 
 	"github.com/hyperledger/transact-sdk-go/crypto"
 	"github.com/hyperledger/transact-sdk-go/errors"
-	"github.com/hyperledger/transact-sdk-go/sabre/addressing"
 	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
 	"github.com/hyperledger/transact-sdk-go/transactions"
 	"github.com/hyperledger/transact-sdk-go/transactions/signing"
@@ -69,8 +68,26 @@ func (s *SabreTransactionBuilder) setTransactionBuilder(t transactions.ITransact
 // Build creates a Sabre Transaction provided a signer of the transaction.
 // Returns an Transaction and an error indicating missing fields
 // or proto marshalling errors, if any
-func (s *SabreTransactionBuilder) Build(signer *signing.Signer) (*transaction_pb2.Transaction, error) {
+func (s *SabreTransactionBuilder) Build(signer signing.ISigner) (*transaction_pb2.Transaction, error) {
+	if s.transactionBuilder == nil {
+		return nil, errors.NewMissingFieldError("transaction builder")
+	}
 
+	return s.build(signer, s.transactionBuilder.GetDependencies())
+}
+
+// BuildWithDependencies creates a Sabre Transaction in the same way as
+// Build, adding the given transaction IDs to the dependencies of the
+// underlying transaction builder. The builder itself is not modified.
+func (s *SabreTransactionBuilder) BuildWithDependencies(signer signing.ISigner, dependencies []string) (*transaction_pb2.Transaction, error) {
+	if s.transactionBuilder == nil {
+		return nil, errors.NewMissingFieldError("transaction builder")
+	}
+
+	return s.build(signer, transactions.MergeDependencies(s.transactionBuilder.GetDependencies(), dependencies))
+}
+
+func (s *SabreTransactionBuilder) build(signer signing.ISigner, dependencies []string) (*transaction_pb2.Transaction, error) {
 	if s.payloadBuilder == nil {
 		return nil, errors.NewMissingFieldError("payload builder")
 	}
@@ -84,27 +101,38 @@ func (s *SabreTransactionBuilder) Build(signer *signing.Signer) (*transaction_pb
 		return nil, err
 	}
 
+	nonce := s.transactionBuilder.GetNonce()
+	if source, ok := s.transactionBuilder.(transactions.INonceSource); ok {
+		if nonce, err = source.NextNonce(); err != nil {
+			return nil, err
+		}
+	}
+
 	signingKey := signer.GetPublicKey()
+	batcherKey := s.transactionBuilder.GetBatcherPublicKey()
+	if batcherKey == nil {
+		batcherKey = signingKey
+	}
 
 	payloadBytes, err := proto.Marshal(sabrePayload)
 	if err != nil {
 		return nil, errors.NewProtobufEncodingError(err)
 	}
 
-	contract := Contract{
-		s.payloadBuilder.GetContractName(),
-		s.payloadBuilder.GetContractVersion(),
+	inputs, outputs, err := RequiredAddresses(sabrePayload, signingKey.AsHex())
+	if err != nil {
+		return nil, err
 	}
 
 	header := &transaction_pb2.TransactionHeader{
 		FamilyName:       SabreFamilyName,
 		FamilyVersion:    SabreFamilyVersion,
-		Inputs:           prepareSabreInputs(s.payloadBuilder.GetInputs(), contract),
-		Outputs:          s.payloadBuilder.GetOutputs(),
+		Inputs:           mergeAddresses(inputs, s.transactionBuilder.GetInputs()),
+		Outputs:          mergeAddresses(outputs, s.transactionBuilder.GetOutputs()),
 		SignerPublicKey:  signingKey.AsHex(),
-		BatcherPublicKey: signingKey.AsHex(),
-		Dependencies:     s.transactionBuilder.GetDependencies(),
-		Nonce:            s.transactionBuilder.GetNonce(),
+		BatcherPublicKey: batcherKey.AsHex(),
+		Dependencies:     dependencies,
+		Nonce:            nonce,
 		PayloadSha512:    crypto.NewSha512Hash(payloadBytes),
 	}
 
@@ -113,7 +141,12 @@ func (s *SabreTransactionBuilder) Build(signer *signing.Signer) (*transaction_pb
 		return nil, errors.NewProtobufEncodingError(err)
 	}
 
-	headerSignature := hex.EncodeToString(signer.Sign(headerBytes))
+	signature, err := signer.Sign(headerBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	headerSignature := hex.EncodeToString(signature)
 
 	return &transaction_pb2.Transaction{
 		Header:          headerBytes,
@@ -121,14 +154,3 @@ func (s *SabreTransactionBuilder) Build(signer *signing.Signer) (*transaction_pb
 		Payload:         payloadBytes,
 	}, nil
 }
-
-func prepareSabreInputs(contractAddresses []string,
-	contract Contract) []string {
-	return []string{
-		addressing.ComputeContractRegistryAddress(contract.Name),
-		addressing.ComputeContractAddress(contract.Name, contract.Version),
-		addressing.CalculateNamespaceRegistryAddress(
-			addressing.ComputeContractPrefix(contract.Name),
-		),
-	}
-}
@@ -0,0 +1,382 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/internal/restclient"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transactions"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultStatusWait   = 30 * time.Second
+)
+
+// IClient defines the interface for submitting batches to a Sawtooth
+// REST API and reading the chain and global state
+type IClient interface {
+	SubmitBatchList(ctx context.Context, batchList *transaction_pb2.BatchList) (string, error)
+	SubmitBatches(ctx context.Context, batches ...*transaction_pb2.Batch) (string, error)
+	GetBatchStatuses(ctx context.Context, ids []string, wait time.Duration) ([]BatchStatus, error)
+	WaitForBatches(ctx context.Context, ids []string, timeout time.Duration) ([]BatchStatus, error)
+	WaitForLink(ctx context.Context, link string, timeout time.Duration) ([]BatchStatus, error)
+	GetState(ctx context.Context, address string) ([]byte, error)
+	ListStatePage(ctx context.Context, prefix string, start string, limit int) (*StatePage, error)
+	ListState(ctx context.Context, prefix string) (map[string][]byte, error)
+	GetBlock(ctx context.Context, id string) (*Block, error)
+	ListBlocks(ctx context.Context, start string, limit int) (*BlockPage, error)
+	GetTransaction(ctx context.Context, id string) (*Transaction, error)
+	ListTransactions(ctx context.Context, start string, limit int) (*TransactionPage, error)
+	GetReceipts(ctx context.Context, ids []string) ([]Receipt, error)
+}
+
+// NewClient returns a new instance of IClient interface for the Sawtooth
+// REST API at baseURL (for example "http://localhost:8008")
+func NewClient(baseURL string, opts ...ClientOption) (IClient, error) {
+	if baseURL == "" {
+		return nil, errors.NewMissingFieldError("base URL")
+	}
+
+	c := &Client{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		httpClient:   http.DefaultClient,
+		pollInterval: defaultPollInterval,
+		statusWait:   defaultStatusWait,
+	}
+	for _, opt := range opts {
+		err := opt(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Client is a Sawtooth REST API client and implements the IClient interface
+type Client struct {
+	baseURL      string
+	httpClient   *http.Client
+	pollInterval time.Duration
+	statusWait   time.Duration
+}
+
+func (c *Client) setHTTPClient(h *http.Client)     { c.httpClient = h }
+func (c *Client) setPollInterval(i time.Duration)  { c.pollInterval = i }
+func (c *Client) setStatusWait(wait time.Duration) { c.statusWait = wait }
+
+// -- Batches --
+
+// SubmitBatchList submits the batch list to the validator. Returns the
+// link to the batches' statuses, or an UnexpectedStatusCodeError if the
+// REST API did not accept the batches.
+func (c *Client) SubmitBatchList(ctx context.Context, batchList *transaction_pb2.BatchList) (string, error) {
+	body, err := proto.Marshal(batchList)
+	if err != nil {
+		return "", errors.NewProtobufEncodingError(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+"/batches", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	var response struct {
+		Link string `json:"link"`
+	}
+	if err := c.do(ctx, req, http.StatusAccepted, &response); err != nil {
+		return "", err
+	}
+	if response.Link == "" {
+		return "", errors.NewMissingFieldError("link")
+	}
+
+	return response.Link, nil
+}
+
+// SubmitBatches submits the batches to the validator in a single batch
+// list. Returns the link to the batches' statuses.
+func (c *Client) SubmitBatches(ctx context.Context, batches ...*transaction_pb2.Batch) (string, error) {
+	batchListBuilder, err := transactions.NewBatchListBuilder(transactions.WithBatches(batches))
+	if err != nil {
+		return "", err
+	}
+	batchList, err := batchListBuilder.BuildBatchList()
+	if err != nil {
+		return "", err
+	}
+
+	return c.SubmitBatchList(ctx, batchList)
+}
+
+// GetBatchStatuses returns the current status of each of the given
+// batches. A non-zero wait lets the REST API hold the request open until
+// the batches complete or the wait elapses.
+func (c *Client) GetBatchStatuses(ctx context.Context, ids []string, wait time.Duration) ([]BatchStatus, error) {
+	query := url.Values{}
+	query.Set("id", strings.Join(ids, ","))
+	if seconds := int(wait / time.Second); seconds > 0 {
+		query.Set("wait", strconv.Itoa(seconds))
+	}
+
+	var response struct {
+		Data []BatchStatus `json:"data"`
+	}
+	if err := c.get(ctx, "/batch_statuses", query, &response); err != nil {
+		return nil, err
+	}
+	return response.Data, nil
+}
+
+// WaitForBatches polls the status of the given batches until every batch
+// is committed, returning their final statuses. Returns an
+// InvalidTransactionError as soon as a batch is rejected, and a
+// StatusTimeoutError if the batches have not completed within timeout.
+// A timeout of zero waits until ctx is done.
+func (c *Client) WaitForBatches(ctx context.Context, ids []string, timeout time.Duration) ([]BatchStatus, error) {
+	poller := restclient.Poller{Interval: c.pollInterval, StatusWait: c.statusWait}
+
+	var statuses []BatchStatus
+	err := poller.WaitForBatches(ctx, ids, timeout, func(wait time.Duration) (restclient.IStatuses, error) {
+		var err error
+		statuses, err = c.GetBatchStatuses(ctx, ids, wait)
+		return batchStatuses(statuses), err
+	})
+	return statuses, err
+}
+
+// WaitForLink waits for the batches named in a link returned by
+// SubmitBatchList or SubmitBatches, in the same way as WaitForBatches
+func (c *Client) WaitForLink(ctx context.Context, link string, timeout time.Duration) ([]BatchStatus, error) {
+	ids, err := BatchIDsFromLink(link)
+	if err != nil {
+		return nil, err
+	}
+	return c.WaitForBatches(ctx, ids, timeout)
+}
+
+// BatchIDsFromLink returns the batch IDs in a batch status link
+func BatchIDsFromLink(link string) ([]string, error) {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return nil, err
+	}
+	ids := parsed.Query().Get("id")
+	if ids == "" {
+		return nil, errors.NewMissingFieldError("id")
+	}
+	return strings.Split(ids, ","), nil
+}
+
+// -- State --
+
+// GetState returns the value stored at the given address in the current
+// state, or a StateNotFoundError if the address has no value
+func (c *Client) GetState(ctx context.Context, address string) ([]byte, error) {
+	var response struct {
+		Data []byte `json:"data"`
+	}
+	err := c.get(ctx, "/state/"+url.PathEscape(address), nil, &response)
+	if statusErr, ok := err.(errors.UnexpectedStatusCodeError); ok && statusErr.StatusCode() == http.StatusNotFound {
+		return nil, errors.NewStateNotFoundError(address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response.Data, nil
+}
+
+// ListStatePage returns one page of the state entries under the given
+// address prefix, beginning at start. An empty start begins at the first
+// entry, and a limit of zero uses the REST API's default page size.
+func (c *Client) ListStatePage(ctx context.Context, prefix string, start string, limit int) (*StatePage, error) {
+	query := pageQuery(start, limit)
+	if prefix != "" {
+		query.Set("address", prefix)
+	}
+
+	page := &StatePage{}
+	if err := c.get(ctx, "/state", query, page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// ListState returns every value stored under the given address prefix,
+// keyed by address, following the REST API's paging. All pages are read
+// from the state at the head of the first page.
+func (c *Client) ListState(ctx context.Context, prefix string) (map[string][]byte, error) {
+	state := make(map[string][]byte)
+	head, start := "", ""
+	for {
+		query := pageQuery(start, 0)
+		if prefix != "" {
+			query.Set("address", prefix)
+		}
+		if head != "" {
+			query.Set("head", head)
+		}
+
+		page := &StatePage{}
+		if err := c.get(ctx, "/state", query, page); err != nil {
+			return nil, err
+		}
+		for _, entry := range page.Data {
+			state[entry.Address] = entry.Data
+		}
+
+		if page.Paging.NextPosition == "" {
+			return state, nil
+		}
+		head, start = page.Head, page.Paging.NextPosition
+	}
+}
+
+// -- Blocks and Transactions --
+
+// GetBlock returns the block with the given ID
+func (c *Client) GetBlock(ctx context.Context, id string) (*Block, error) {
+	var response struct {
+		Data *Block `json:"data"`
+	}
+	if err := c.get(ctx, "/blocks/"+url.PathEscape(id), nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Data, nil
+}
+
+// ListBlocks returns one page of blocks, newest first, beginning at start.
+// An empty start begins at the chain head, and a limit of zero uses the
+// REST API's default page size.
+func (c *Client) ListBlocks(ctx context.Context, start string, limit int) (*BlockPage, error) {
+	page := &BlockPage{}
+	if err := c.get(ctx, "/blocks", pageQuery(start, limit), page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetTransaction returns the committed transaction with the given ID
+func (c *Client) GetTransaction(ctx context.Context, id string) (*Transaction, error) {
+	var response struct {
+		Data *Transaction `json:"data"`
+	}
+	if err := c.get(ctx, "/transactions/"+url.PathEscape(id), nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Data, nil
+}
+
+// ListTransactions returns one page of committed transactions beginning
+// at start. An empty start begins at the chain head, and a limit of zero
+// uses the REST API's default page size.
+func (c *Client) ListTransactions(ctx context.Context, start string, limit int) (*TransactionPage, error) {
+	page := &TransactionPage{}
+	if err := c.get(ctx, "/transactions", pageQuery(start, limit), page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetReceipts returns the receipts of the given committed transactions
+func (c *Client) GetReceipts(ctx context.Context, ids []string) ([]Receipt, error) {
+	query := url.Values{}
+	query.Set("id", strings.Join(ids, ","))
+
+	var response struct {
+		Data []Receipt `json:"data"`
+	}
+	if err := c.get(ctx, "/receipts", query, &response); err != nil {
+		return nil, err
+	}
+	return response.Data, nil
+}
+
+// ---
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, result interface{}) error {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, req, http.StatusOK, result)
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, expected int, result interface{}) error {
+	return restclient.Do(ctx, c.httpClient, req, expected, result, errorMessage)
+}
+
+// errorMessage returns the title and message of a REST API error response,
+// or the body itself if it is not one
+func errorMessage(body []byte) string {
+	var response struct {
+		Error struct {
+			Code    int    `json:"code"`
+			Title   string `json:"title"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil || response.Error.Title == "" {
+		return strings.TrimSpace(string(body))
+	}
+	return fmt.Sprintf("%s (%d): %s", response.Error.Title, response.Error.Code, response.Error.Message)
+}
+
+func pageQuery(start string, limit int) url.Values {
+	query := url.Values{}
+	if start != "" {
+		query.Set("start", start)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	return query
+}
+
+// batchStatuses implements restclient.IStatuses for REST API batch statuses
+type batchStatuses []BatchStatus
+
+func (b batchStatuses) Len() int               { return len(b) }
+func (b batchStatuses) ID(i int) string        { return b[i].ID }
+func (b batchStatuses) IsCommitted(i int) bool { return b[i].Status == StatusCommitted }
+func (b batchStatuses) IsInvalid(i int) bool   { return b[i].Status == StatusInvalid }
+
+func (b batchStatuses) InvalidTransaction(i int) (string, string, bool) {
+	if len(b[i].InvalidTransactions) == 0 {
+		return "", "", false
+	}
+	txn := b[i].InvalidTransactions[0]
+	return txn.ID, txn.Message, true
+}
@@ -0,0 +1,488 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/internal/testutil"
+	"github.com/hyperledger/transact-sdk-go/sawtooth/rest"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+)
+
+const (
+	BLOCKID   = "b10c"
+	STATEHEAD = "5ea0"
+)
+
+// restServer is an httptest stand-in for the Sawtooth REST API. Batches
+// are PENDING for their first status request, then COMMITTED, or INVALID
+// if their first transaction's payload is "bad". State is served in pages
+// of two entries.
+type restServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	batches []*transaction_pb2.Batch
+	polls   map[string]int
+	state   map[string][]byte
+}
+
+func newRestServer(state map[string][]byte) *restServer {
+	s := &restServer{polls: make(map[string]int), state: state}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/batches", s.handleBatches)
+	mux.HandleFunc("/batch_statuses", s.handleBatchStatuses)
+	mux.HandleFunc("/state", s.handleListState)
+	mux.HandleFunc("/state/", s.handleGetState)
+	mux.HandleFunc("/blocks", s.handleBlocks)
+	mux.HandleFunc("/blocks/", s.handleBlocks)
+	mux.HandleFunc("/transactions", s.handleTransactions)
+	mux.HandleFunc("/transactions/", s.handleTransactions)
+	mux.HandleFunc("/receipts", s.handleReceipts)
+
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, code int, title string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{"code": code, "title": title, "message": title + "."},
+	})
+}
+
+func (s *restServer) handleBatches(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	batchList := &transaction_pb2.BatchList{}
+	if r.Method != http.MethodPost || proto.Unmarshal(body, batchList) != nil || len(batchList.GetBatches()) == 0 {
+		writeError(w, http.StatusBadRequest, 35, "Protobuf Not Decodable")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for _, batch := range batchList.GetBatches() {
+		s.batches = append(s.batches, batch)
+		ids = append(ids, batch.GetHeaderSignature())
+	}
+	writeJSON(w, http.StatusAccepted, map[string]string{
+		"link": s.URL + "/batch_statuses?id=" + strings.Join(ids, ","),
+	})
+}
+
+func (s *restServer) handleBatchStatuses(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var data []map[string]interface{}
+	for _, id := range strings.Split(r.URL.Query().Get("id"), ",") {
+		status := map[string]interface{}{"id": id, "invalid_transactions": []interface{}{}}
+		s.polls[id]++
+		batch := s.batch(id)
+		switch {
+		case batch == nil:
+			status["status"] = rest.StatusUnknown
+		case string(batch.GetTransactions()[0].GetPayload()) == "bad":
+			status["status"] = rest.StatusInvalid
+			status["invalid_transactions"] = []map[string]string{{
+				"id":            batch.GetTransactions()[0].GetHeaderSignature(),
+				"message":       "bad payload",
+				"extended_data": base64.StdEncoding.EncodeToString([]byte{0x01}),
+			}}
+		case s.polls[id] == 1:
+			status["status"] = rest.StatusPending
+		default:
+			status["status"] = rest.StatusCommitted
+		}
+		data = append(data, status)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "link": r.URL.String()})
+}
+
+func (s *restServer) batch(id string) *transaction_pb2.Batch {
+	for _, batch := range s.batches {
+		if batch.GetHeaderSignature() == id {
+			return batch
+		}
+	}
+	return nil
+}
+
+func (s *restServer) handleGetState(w http.ResponseWriter, r *http.Request) {
+	value, ok := s.state[strings.TrimPrefix(r.URL.Path, "/state/")]
+	if !ok {
+		writeError(w, http.StatusNotFound, 75, "State Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": value, "head": STATEHEAD})
+}
+
+func (s *restServer) handleListState(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if head := query.Get("head"); head != "" && head != STATEHEAD {
+		writeError(w, http.StatusNotFound, 50, "Head Not Found")
+		return
+	}
+
+	var addresses []string
+	for address := range s.state {
+		if strings.HasPrefix(address, query.Get("address")) && address >= query.Get("start") {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Strings(addresses)
+
+	limit := 2
+	if l, err := strconv.Atoi(query.Get("limit")); err == nil {
+		limit = l
+	}
+	paging := map[string]interface{}{"limit": limit, "start": query.Get("start")}
+	if len(addresses) > limit {
+		paging["next_position"] = addresses[limit]
+		paging["next"] = s.URL + "/state?start=" + addresses[limit]
+		addresses = addresses[:limit]
+	}
+
+	data := []map[string]interface{}{}
+	for _, address := range addresses {
+		data = append(data, map[string]interface{}{"address": address, "data": s.state[address]})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "head": STATEHEAD, "paging": paging})
+}
+
+func (s *restServer) transactionJSON(txn *transaction_pb2.Transaction) map[string]interface{} {
+	header := &transaction_pb2.TransactionHeader{}
+	proto.Unmarshal(txn.GetHeader(), header)
+	return map[string]interface{}{
+		"header": map[string]interface{}{
+			"batcher_public_key": header.GetBatcherPublicKey(),
+			"dependencies":       header.GetDependencies(),
+			"family_name":        header.GetFamilyName(),
+			"family_version":     header.GetFamilyVersion(),
+			"inputs":             header.GetInputs(),
+			"nonce":              header.GetNonce(),
+			"outputs":            header.GetOutputs(),
+			"payload_sha512":     header.GetPayloadSha512(),
+			"signer_public_key":  header.GetSignerPublicKey(),
+		},
+		"header_signature": txn.GetHeaderSignature(),
+		"payload":          txn.GetPayload(),
+	}
+}
+
+func (s *restServer) blockJSON() map[string]interface{} {
+	var batchIDs []string
+	var batches []map[string]interface{}
+	for _, batch := range s.batches {
+		header := &transaction_pb2.BatchHeader{}
+		proto.Unmarshal(batch.GetHeader(), header)
+		var txns []map[string]interface{}
+		for _, txn := range batch.GetTransactions() {
+			txns = append(txns, s.transactionJSON(txn))
+		}
+		batchIDs = append(batchIDs, batch.GetHeaderSignature())
+		batches = append(batches, map[string]interface{}{
+			"header": map[string]interface{}{
+				"signer_public_key": header.GetSignerPublicKey(),
+				"transaction_ids":   header.GetTransactionIds(),
+			},
+			"header_signature": batch.GetHeaderSignature(),
+			"trace":            batch.GetTrace(),
+			"transactions":     txns,
+		})
+	}
+	return map[string]interface{}{
+		"header": map[string]interface{}{
+			"batch_ids":         batchIDs,
+			"block_num":         "7",
+			"consensus":         base64.StdEncoding.EncodeToString([]byte("Devmode")),
+			"previous_block_id": "0000000000000000",
+			"signer_public_key": "02d1fbda50dbcd0d3c286a6a9fa71aa7ce2d97159b90ddd463e0816422d621e135",
+			"state_root_hash":   STATEHEAD,
+		},
+		"header_signature": BLOCKID,
+		"batches":          batches,
+	}
+}
+
+func (s *restServer) handleBlocks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id := strings.TrimPrefix(r.URL.Path, "/blocks/"); id != r.URL.Path {
+		if id != BLOCKID {
+			writeError(w, http.StatusNotFound, 70, "Block Not Found")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": s.blockJSON()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data":   []interface{}{s.blockJSON()},
+		"head":   BLOCKID,
+		"paging": map[string]interface{}{"limit": 100, "start": BLOCKID},
+	})
+}
+
+func (s *restServer) handleTransactions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var txns []map[string]interface{}
+	id := strings.TrimPrefix(r.URL.Path, "/transactions/")
+	for _, batch := range s.batches {
+		for _, txn := range batch.GetTransactions() {
+			if id == txn.GetHeaderSignature() {
+				writeJSON(w, http.StatusOK, map[string]interface{}{"data": s.transactionJSON(txn)})
+				return
+			}
+			txns = append(txns, s.transactionJSON(txn))
+		}
+	}
+	if id != r.URL.Path {
+		writeError(w, http.StatusNotFound, 72, "Transaction Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data":   txns,
+		"head":   BLOCKID,
+		"paging": map[string]interface{}{"limit": 100},
+	})
+}
+
+func (s *restServer) handleReceipts(w http.ResponseWriter, r *http.Request) {
+	var data []map[string]interface{}
+	for _, id := range strings.Split(r.URL.Query().Get("id"), ",") {
+		data = append(data, map[string]interface{}{
+			"transaction_id": id,
+			"state_changes": []map[string]interface{}{
+				{"address": "1cf126" + strings.Repeat("0", 64), "value": []byte{0x0a}, "type": "SET"},
+			},
+			"events": []map[string]interface{}{{
+				"event_type": "intkey/set",
+				"attributes": []map[string]string{{"key": "name", "value": "a"}},
+				"data":       []byte("event"),
+			}},
+			"data": [][]byte{[]byte("receipt")},
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+func newClient(t *testing.T, server *restServer) rest.IClient {
+	client, err := rest.NewClient(server.URL,
+		rest.WithPollInterval(time.Millisecond),
+		rest.WithStatusWait(0),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestSubmitAndWait(t *testing.T) {
+	server := newRestServer(nil)
+	defer server.Close()
+	client := newClient(t, server)
+	ctx := context.Background()
+
+	batch := testutil.BuildBatch(t, "one")
+	link, err := client.SubmitBatches(ctx, batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses, err := client.WaitForLink(ctx, link, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0].ID != batch.GetHeaderSignature() || statuses[0].Status != rest.StatusCommitted {
+		t.Errorf("Unexpected statuses %+v", statuses)
+	}
+
+	bad := testutil.BuildBatch(t, "bad")
+	link, err = client.SubmitBatches(ctx, bad)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses, err = client.WaitForLink(ctx, link, time.Second)
+	invalidErr, ok := err.(errors.InvalidTransactionError)
+	if !ok {
+		t.Fatal("Expected InvalidTransactionError, got", err)
+	}
+	if invalidErr.TransactionID() != bad.GetTransactions()[0].GetHeaderSignature() || invalidErr.Message() != "bad payload" {
+		t.Error("InvalidTransactionError has wrong details:", invalidErr)
+	}
+	if string(statuses[0].InvalidTransactions[0].ExtendedData) != string([]byte{0x01}) {
+		t.Error("Invalid transaction has wrong extended data")
+	}
+
+	_, err = client.WaitForBatches(ctx, []string{"unknown"}, 10*time.Millisecond)
+	if _, ok := err.(errors.StatusTimeoutError); !ok {
+		t.Error("Expected StatusTimeoutError, got", err)
+	}
+
+	_, err = client.SubmitBatchList(ctx, &transaction_pb2.BatchList{})
+	statusErr, ok := err.(errors.UnexpectedStatusCodeError)
+	if !ok || statusErr.StatusCode() != http.StatusBadRequest {
+		t.Error("Expected a 400 UnexpectedStatusCodeError, got", err)
+	}
+	if !strings.Contains(fmt.Sprint(err), "Protobuf Not Decodable (35)") {
+		t.Error("Expected the REST API error title in the error, got", err)
+	}
+}
+
+func TestState(t *testing.T) {
+	state := map[string][]byte{}
+	for i := 0; i < 5; i++ {
+		state[fmt.Sprintf("1cf126%064d", i)] = []byte{byte(i)}
+	}
+	state["000000"+strings.Repeat("0", 64)] = []byte("settings")
+
+	server := newRestServer(state)
+	defer server.Close()
+	client := newClient(t, server)
+	ctx := context.Background()
+
+	address := fmt.Sprintf("1cf126%064d", 3)
+	value, err := client.GetState(ctx, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != string([]byte{3}) {
+		t.Errorf("Unexpected value %v", value)
+	}
+
+	_, err = client.GetState(ctx, fmt.Sprintf("1cf126%064d", 9))
+	if _, ok := err.(errors.StateNotFoundError); !ok {
+		t.Error("Expected StateNotFoundError, got", err)
+	}
+
+	page, err := client.ListStatePage(ctx, "1cf126", "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Data) != 2 || page.Head != STATEHEAD || page.Paging.NextPosition != fmt.Sprintf("1cf126%064d", 2) {
+		t.Errorf("Unexpected page %+v", page)
+	}
+
+	listed, err := client.ListState(ctx, "1cf126")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 5 {
+		t.Errorf("Expected 5 entries across pages, got %d", len(listed))
+	}
+	for i := 0; i < 5; i++ {
+		if string(listed[fmt.Sprintf("1cf126%064d", i)]) != string([]byte{byte(i)}) {
+			t.Errorf("Entry %d has the wrong value", i)
+		}
+	}
+}
+
+func TestBlocksTransactionsAndReceipts(t *testing.T) {
+	server := newRestServer(nil)
+	defer server.Close()
+	client := newClient(t, server)
+	ctx := context.Background()
+
+	batch := testutil.BuildBatch(t, "one")
+	if _, err := client.SubmitBatches(ctx, batch); err != nil {
+		t.Fatal(err)
+	}
+	txnID := batch.GetTransactions()[0].GetHeaderSignature()
+
+	blocks, err := client.ListBlocks(ctx, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks.Data) != 1 {
+		t.Fatalf("Expected one block, got %d", len(blocks.Data))
+	}
+	block := blocks.Data[0]
+	if block.Header.BlockNum != 7 || string(block.Header.Consensus) != "Devmode" || block.Header.StateRootHash != STATEHEAD {
+		t.Errorf("Unexpected block header %+v", block.Header)
+	}
+	if len(block.Batches) != 1 || block.Batches[0].HeaderSignature != batch.GetHeaderSignature() ||
+		block.Batches[0].Header.TransactionIDs[0] != txnID {
+		t.Errorf("Unexpected block batches %+v", block.Batches)
+	}
+
+	single, err := client.GetBlock(ctx, BLOCKID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if single.HeaderSignature != BLOCKID {
+		t.Error("Fetched the wrong block")
+	}
+	if _, err := client.GetBlock(ctx, "missing"); err == nil {
+		t.Error("Expected an error for a missing block")
+	}
+
+	txns, err := client.ListTransactions(ctx, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txns.Data) != 1 || txns.Data[0].HeaderSignature != txnID {
+		t.Errorf("Unexpected transactions %+v", txns.Data)
+	}
+
+	txn, err := client.GetTransaction(ctx, txnID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(txn.Payload) != "one" || txn.Header.FamilyName != "intkey" || txn.Header.Inputs[0] != "1cf126" {
+		t.Errorf("Unexpected transaction %+v", txn)
+	}
+
+	receipts, err := client.GetReceipts(ctx, []string{txnID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(receipts) != 1 {
+		t.Fatalf("Expected one receipt, got %d", len(receipts))
+	}
+	receipt := receipts[0]
+	if receipt.TransactionID != txnID || receipt.StateChanges[0].Type != "SET" ||
+		string(receipt.StateChanges[0].Value) != string([]byte{0x0a}) ||
+		receipt.Events[0].Attributes[0].Value != "a" || string(receipt.Events[0].Data) != "event" ||
+		string(receipt.Data[0]) != "receipt" {
+		t.Errorf("Unexpected receipt %+v", receipt)
+	}
+}
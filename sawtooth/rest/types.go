@@ -0,0 +1,155 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package rest
+
+// Batch status values reported by the Sawtooth REST API
+const (
+	StatusCommitted = "COMMITTED"
+	StatusInvalid   = "INVALID"
+	StatusPending   = "PENDING"
+	StatusUnknown   = "UNKNOWN"
+)
+
+// BatchStatus is the status of a submitted batch as reported by the
+// batch_statuses endpoint
+type BatchStatus struct {
+	ID                  string               `json:"id"`
+	Status              string               `json:"status"`
+	InvalidTransactions []InvalidTransaction `json:"invalid_transactions"`
+}
+
+// InvalidTransaction describes a transaction that was rejected
+type InvalidTransaction struct {
+	ID           string `json:"id"`
+	Message      string `json:"message"`
+	ExtendedData []byte `json:"extended_data"`
+}
+
+// Paging describes the position of a page of results. Pass NextPosition as
+// the start of the next request to continue listing; it is empty on the
+// last page.
+type Paging struct {
+	Start        string `json:"start"`
+	Limit        int    `json:"limit"`
+	NextPosition string `json:"next_position"`
+	Next         string `json:"next"`
+}
+
+// StateEntry is a value stored in global state
+type StateEntry struct {
+	Address string `json:"address"`
+	Data    []byte `json:"data"`
+}
+
+// StatePage is a page of state entries
+type StatePage struct {
+	Data   []StateEntry `json:"data"`
+	Head   string       `json:"head"`
+	Paging Paging       `json:"paging"`
+}
+
+// TransactionHeader is the decoded header of a Transaction
+type TransactionHeader struct {
+	BatcherPublicKey string   `json:"batcher_public_key"`
+	Dependencies     []string `json:"dependencies"`
+	FamilyName       string   `json:"family_name"`
+	FamilyVersion    string   `json:"family_version"`
+	Inputs           []string `json:"inputs"`
+	Nonce            string   `json:"nonce"`
+	Outputs          []string `json:"outputs"`
+	PayloadSha512    string   `json:"payload_sha512"`
+	SignerPublicKey  string   `json:"signer_public_key"`
+}
+
+// Transaction is a Transaction with its header decoded
+type Transaction struct {
+	Header          TransactionHeader `json:"header"`
+	HeaderSignature string            `json:"header_signature"`
+	Payload         []byte            `json:"payload"`
+}
+
+// TransactionPage is a page of transactions
+type TransactionPage struct {
+	Data   []Transaction `json:"data"`
+	Head   string        `json:"head"`
+	Paging Paging        `json:"paging"`
+}
+
+// BatchHeader is the decoded header of a Batch
+type BatchHeader struct {
+	SignerPublicKey string   `json:"signer_public_key"`
+	TransactionIDs  []string `json:"transaction_ids"`
+}
+
+// Batch is a Batch with its headers decoded
+type Batch struct {
+	Header          BatchHeader   `json:"header"`
+	HeaderSignature string        `json:"header_signature"`
+	Trace           bool          `json:"trace"`
+	Transactions    []Transaction `json:"transactions"`
+}
+
+// BlockHeader is the decoded header of a Block
+type BlockHeader struct {
+	BatchIDs        []string `json:"batch_ids"`
+	BlockNum        uint64   `json:"block_num,string"`
+	Consensus       []byte   `json:"consensus"`
+	PreviousBlockID string   `json:"previous_block_id"`
+	SignerPublicKey string   `json:"signer_public_key"`
+	StateRootHash   string   `json:"state_root_hash"`
+}
+
+// Block is a Block with its headers decoded
+type Block struct {
+	Header          BlockHeader `json:"header"`
+	HeaderSignature string      `json:"header_signature"`
+	Batches         []Batch     `json:"batches"`
+}
+
+// BlockPage is a page of blocks, newest first
+type BlockPage struct {
+	Data   []Block `json:"data"`
+	Head   string  `json:"head"`
+	Paging Paging  `json:"paging"`
+}
+
+// Receipt is the result of executing a committed transaction
+type Receipt struct {
+	TransactionID string        `json:"transaction_id"`
+	StateChanges  []StateChange `json:"state_changes"`
+	Events        []Event       `json:"events"`
+	Data          [][]byte      `json:"data"`
+}
+
+// StateChange is a change a transaction made to global state
+type StateChange struct {
+	Address string `json:"address"`
+	Value   []byte `json:"value"`
+	Type    string `json:"type"`
+}
+
+// Event is an event emitted by a transaction
+type Event struct {
+	EventType  string           `json:"event_type"`
+	Attributes []EventAttribute `json:"attributes"`
+	Data       []byte           `json:"data"`
+}
+
+// EventAttribute is a key and value describing an Event
+type EventAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
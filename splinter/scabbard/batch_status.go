@@ -0,0 +1,57 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package scabbard
+
+// Batch status types reported by Scabbard
+const (
+	StatusPending   = "Pending"
+	StatusInvalid   = "Invalid"
+	StatusValid     = "Valid"
+	StatusCommitted = "Committed"
+	StatusUnknown   = "Unknown"
+)
+
+// BatchInfo is the status of a submitted batch as reported by the
+// Scabbard batch_statuses endpoint
+type BatchInfo struct {
+	ID        string      `json:"id"`
+	Status    BatchStatus `json:"status"`
+	Timestamp Timestamp   `json:"timestamp"`
+}
+
+// BatchStatus is the state of a batch and its transactions. Only the
+// transactions of an invalid batch carry an error message.
+type BatchStatus struct {
+	StatusType string               `json:"statusType"`
+	Message    []InvalidTransaction `json:"message"`
+}
+
+// InvalidTransaction describes a transaction that was rejected
+type InvalidTransaction struct {
+	TransactionID string `json:"transaction_id"`
+	ErrorMessage  string `json:"error_message"`
+}
+
+// Timestamp is the time a batch status was last updated
+type Timestamp struct {
+	SecsSinceEpoch  int64 `json:"secs_since_epoch"`
+	NanosSinceEpoch int64 `json:"nanos_since_epoch"`
+}
+
+// IsComplete reports whether the batch has been committed or rejected
+func (b BatchInfo) IsComplete() bool {
+	return b.Status.StatusType == StatusCommitted || b.Status.StatusType == StatusInvalid
+}
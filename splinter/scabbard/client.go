@@ -0,0 +1,235 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package scabbard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/internal/restclient"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transactions"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultStatusWait   = 30 * time.Second
+)
+
+// IClient defines the interface for submitting batches to a Scabbard
+// service, following their progress, and reading its state
+type IClient interface {
+	SubmitBatchList(ctx context.Context, batchList *transaction_pb2.BatchList) (string, error)
+	SubmitBatches(ctx context.Context, batches ...*transaction_pb2.Batch) (string, error)
+	GetBatchStatuses(ctx context.Context, ids []string, wait time.Duration) ([]BatchInfo, error)
+	WaitForBatches(ctx context.Context, ids []string, timeout time.Duration) ([]BatchInfo, error)
+	WaitForLink(ctx context.Context, link string, timeout time.Duration) ([]BatchInfo, error)
+	GetState(ctx context.Context, address string) ([]byte, error)
+	ListState(ctx context.Context, prefix string) (map[string][]byte, error)
+	GetStateRoot(ctx context.Context) (string, error)
+	GetDeploymentState(ctx context.Context, contractName, deploymentName string) ([]byte, error)
+}
+
+// NewClient returns a new instance of IClient interface for the Scabbard
+// service serviceID on circuit circuitID, served by the Splinter REST API
+// at baseURL (for example "http://localhost:8088")
+func NewClient(baseURL, circuitID, serviceID string, opts ...ClientOption) (IClient, error) {
+	if baseURL == "" {
+		return nil, errors.NewMissingFieldError("base URL")
+	}
+	if circuitID == "" {
+		return nil, errors.NewMissingFieldError("circuit ID")
+	}
+	if serviceID == "" {
+		return nil, errors.NewMissingFieldError("service ID")
+	}
+
+	c := &Client{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		circuitID:    circuitID,
+		serviceID:    serviceID,
+		httpClient:   http.DefaultClient,
+		pollInterval: defaultPollInterval,
+		statusWait:   defaultStatusWait,
+	}
+	for _, opt := range opts {
+		err := opt(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Client is a Splinter REST API client for a single Scabbard service and
+// implements the IClient interface
+type Client struct {
+	baseURL      string
+	circuitID    string
+	serviceID    string
+	httpClient   *http.Client
+	pollInterval time.Duration
+	statusWait   time.Duration
+}
+
+func (c *Client) setHTTPClient(h *http.Client)     { c.httpClient = h }
+func (c *Client) setPollInterval(i time.Duration)  { c.pollInterval = i }
+func (c *Client) setStatusWait(wait time.Duration) { c.statusWait = wait }
+
+func (c *Client) servicePath(endpoint string) string {
+	return fmt.Sprintf("%s/scabbard/%s/%s/%s", c.baseURL, c.circuitID, c.serviceID, endpoint)
+}
+
+// SubmitBatchList submits the batch list to the service. Returns the link
+// to the batches' statuses, or an UnexpectedStatusCodeError if Scabbard
+// did not accept the batches.
+func (c *Client) SubmitBatchList(ctx context.Context, batchList *transaction_pb2.BatchList) (string, error) {
+	body, err := proto.Marshal(batchList)
+	if err != nil {
+		return "", errors.NewProtobufEncodingError(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.servicePath("batches"), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	var response struct {
+		Link string `json:"link"`
+	}
+	if err := c.do(ctx, req, http.StatusAccepted, &response); err != nil {
+		return "", err
+	}
+	if response.Link == "" {
+		return "", errors.NewMissingFieldError("link")
+	}
+
+	return response.Link, nil
+}
+
+// SubmitBatches submits the batches to the service in a single batch list.
+// Returns the link to the batches' statuses.
+func (c *Client) SubmitBatches(ctx context.Context, batches ...*transaction_pb2.Batch) (string, error) {
+	batchListBuilder, err := transactions.NewBatchListBuilder(transactions.WithBatches(batches))
+	if err != nil {
+		return "", err
+	}
+	batchList, err := batchListBuilder.BuildBatchList()
+	if err != nil {
+		return "", err
+	}
+
+	return c.SubmitBatchList(ctx, batchList)
+}
+
+// GetBatchStatuses returns the current status of each of the given
+// batches. A non-zero wait lets Scabbard hold the request open until the
+// batches complete or the wait elapses.
+func (c *Client) GetBatchStatuses(ctx context.Context, ids []string, wait time.Duration) ([]BatchInfo, error) {
+	query := url.Values{}
+	query.Set("ids", strings.Join(ids, ","))
+	if seconds := int(wait / time.Second); seconds > 0 {
+		query.Set("wait", strconv.Itoa(seconds))
+	}
+
+	req, err := http.NewRequest(http.MethodGet, c.servicePath("batch_statuses")+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []BatchInfo
+	if err := c.do(ctx, req, http.StatusOK, &infos); err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+// WaitForBatches polls the status of the given batches until every batch
+// is committed, returning their final statuses. Returns an
+// InvalidTransactionError as soon as a batch is rejected, and a
+// StatusTimeoutError if the batches have not completed within timeout.
+// A timeout of zero waits until ctx is done.
+func (c *Client) WaitForBatches(ctx context.Context, ids []string, timeout time.Duration) ([]BatchInfo, error) {
+	poller := restclient.Poller{Interval: c.pollInterval, StatusWait: c.statusWait}
+
+	var infos []BatchInfo
+	err := poller.WaitForBatches(ctx, ids, timeout, func(wait time.Duration) (restclient.IStatuses, error) {
+		var err error
+		infos, err = c.GetBatchStatuses(ctx, ids, wait)
+		if statusErr, ok := err.(errors.UnexpectedStatusCodeError); ok &&
+			statusErr.StatusCode() == http.StatusRequestTimeout {
+			// Scabbard gave up waiting before the batches completed
+			infos, err = nil, nil
+		}
+		return batchInfos(infos), err
+	})
+	return infos, err
+}
+
+// WaitForLink waits for the batches named in a link returned by
+// SubmitBatchList or SubmitBatches, in the same way as WaitForBatches
+func (c *Client) WaitForLink(ctx context.Context, link string, timeout time.Duration) ([]BatchInfo, error) {
+	ids, err := BatchIDsFromLink(link)
+	if err != nil {
+		return nil, err
+	}
+	return c.WaitForBatches(ctx, ids, timeout)
+}
+
+// BatchIDsFromLink returns the batch IDs in a batch status link
+func BatchIDsFromLink(link string) ([]string, error) {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return nil, err
+	}
+	ids := parsed.Query().Get("ids")
+	if ids == "" {
+		return nil, errors.NewMissingFieldError("ids")
+	}
+	return strings.Split(ids, ","), nil
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, expected int, result interface{}) error {
+	return restclient.Do(ctx, c.httpClient, req, expected, result, nil)
+}
+
+// ---
+
+// batchInfos implements restclient.IStatuses for Scabbard batch statuses
+type batchInfos []BatchInfo
+
+func (b batchInfos) Len() int               { return len(b) }
+func (b batchInfos) ID(i int) string        { return b[i].ID }
+func (b batchInfos) IsCommitted(i int) bool { return b[i].Status.StatusType == StatusCommitted }
+func (b batchInfos) IsInvalid(i int) bool   { return b[i].Status.StatusType == StatusInvalid }
+
+func (b batchInfos) InvalidTransaction(i int) (string, string, bool) {
+	if len(b[i].Status.Message) == 0 {
+		return "", "", false
+	}
+	txn := b[i].Status.Message[0]
+	return txn.TransactionID, txn.ErrorMessage, true
+}
@@ -0,0 +1,52 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package scabbard
+
+import (
+	"net/http"
+	"time"
+)
+
+// ClientOption provides the functional options for creating a Scabbard Client
+type ClientOption func(*Client) error
+
+// WithHTTPClient sets the Client Option for the http.Client used to make
+// requests. Defaults to http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) error {
+		c.setHTTPClient(httpClient)
+		return nil
+	}
+}
+
+// WithPollInterval sets the Client Option for how long to pause between
+// batch status requests while waiting for batches. Defaults to one second.
+func WithPollInterval(interval time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.setPollInterval(interval)
+		return nil
+	}
+}
+
+// WithStatusWait sets the Client Option for how long Scabbard may hold
+// each batch status request open waiting for the batches to complete.
+// Defaults to 30 seconds.
+func WithStatusWait(wait time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.setStatusWait(wait)
+		return nil
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package scabbard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/sabre/addressing"
+)
+
+// GetState returns the value stored at the given address, or a
+// StateNotFoundError if the address has no value
+func (c *Client) GetState(ctx context.Context, address string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, c.servicePath("state/"+url.PathEscape(address)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var value byteArray
+	err = c.do(ctx, req, http.StatusOK, &value)
+	if statusErr, ok := err.(errors.UnexpectedStatusCodeError); ok && statusErr.StatusCode() == http.StatusNotFound {
+		return nil, errors.NewStateNotFoundError(address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// ListState returns the values stored under the given address prefix,
+// keyed by address. An empty prefix lists all of the service's state.
+func (c *Client) ListState(ctx context.Context, prefix string) (map[string][]byte, error) {
+	path := c.servicePath("state")
+	if prefix != "" {
+		path += "?" + url.Values{"prefix": {prefix}}.Encode()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []struct {
+		Address string    `json:"address"`
+		Value   byteArray `json:"value"`
+	}
+	if err := c.do(ctx, req, http.StatusOK, &entries); err != nil {
+		return nil, err
+	}
+
+	state := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		state[entry.Address] = entry.Value
+	}
+	return state, nil
+}
+
+// GetStateRoot returns the service's current state root hash
+func (c *Client) GetStateRoot(ctx context.Context) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, c.servicePath("state_root"), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var stateRoot string
+	if err := c.do(ctx, req, http.StatusOK, &stateRoot); err != nil {
+		return "", err
+	}
+	return stateRoot, nil
+}
+
+// GetDeploymentState returns the value stored at the address of a
+// contract deployment, such as an XO game, as calculated by
+// addressing.CalculateDeploymentAddress
+func (c *Client) GetDeploymentState(ctx context.Context, contractName, deploymentName string) ([]byte, error) {
+	return c.GetState(ctx, addressing.CalculateDeploymentAddress(contractName, deploymentName))
+}
+
+// byteArray decodes the JSON arrays of numbers Scabbard uses for state
+// values, which encoding/json would otherwise expect as base64 strings
+type byteArray []byte
+
+func (b *byteArray) UnmarshalJSON(data []byte) error {
+	var values []int
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	bytes := make([]byte, len(values))
+	for i, value := range values {
+		if value < 0 || value > 255 {
+			return fmt.Errorf("state value byte out of range: %d", value)
+		}
+		bytes[i] = byte(value)
+	}
+	*b = bytes
+	return nil
+}
@@ -0,0 +1,253 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/internal/testutil"
+	"github.com/hyperledger/transact-sdk-go/splinter/scabbard"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+)
+
+const (
+	CIRCUIT = "01234-ABCDE"
+	SERVICE = "a000"
+)
+
+// Scabbard batch_statuses entries, as Scabbard encodes them. Committed
+// and valid batches list their transactions without an error.
+const (
+	committedJSON = `{"id":"%s","status":{"statusType":"Committed","message":[%s]},"timestamp":{"secs_since_epoch":1602000000,"nanos_since_epoch":0}}`
+	pendingJSON   = `{"id":"%s","status":{"statusType":"Pending","message":[]},"timestamp":{"secs_since_epoch":1602000000,"nanos_since_epoch":0}}`
+	invalidJSON   = `{"id":"%s","status":{"statusType":"Invalid","message":[{"transaction_id":"%s","error_message":"bad payload","error_data":[]}]},"timestamp":{"secs_since_epoch":1602000000,"nanos_since_epoch":0}}`
+	validTxnJSON  = `{"transaction_id":"%s"}`
+)
+
+// scabbardServer is an httptest stand-in for a Scabbard service. Batches
+// report Pending until they have been polled pendingPolls times, then
+// Committed, or Invalid if their first transaction's payload is "bad".
+type scabbardServer struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	pendingPolls int
+	polls        map[string]int
+	txns         map[string][]string
+	invalid      map[string]string
+}
+
+func newScabbardServer(t *testing.T, pendingPolls int) *scabbardServer {
+	s := &scabbardServer{
+		pendingPolls: pendingPolls,
+		polls:        make(map[string]int),
+		txns:         make(map[string][]string),
+		invalid:      make(map[string]string),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/scabbard/"+CIRCUIT+"/"+SERVICE+"/batches", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		batchList := &transaction_pb2.BatchList{}
+		if r.Method != http.MethodPost || proto.Unmarshal(body, batchList) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message": "invalid batch list"}`))
+			return
+		}
+
+		s.mu.Lock()
+		var ids []string
+		for _, batch := range batchList.GetBatches() {
+			ids = append(ids, batch.GetHeaderSignature())
+			for _, txn := range batch.GetTransactions() {
+				s.txns[batch.GetHeaderSignature()] = append(s.txns[batch.GetHeaderSignature()], txn.GetHeaderSignature())
+			}
+			txn := batch.GetTransactions()[0]
+			if string(txn.GetPayload()) == "bad" {
+				s.invalid[batch.GetHeaderSignature()] = txn.GetHeaderSignature()
+			}
+		}
+		s.mu.Unlock()
+
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]string{
+			"link": "/scabbard/" + CIRCUIT + "/" + SERVICE + "/batch_statuses?ids=" + strings.Join(ids, ","),
+		})
+	})
+	mux.HandleFunc("/scabbard/"+CIRCUIT+"/"+SERVICE+"/batch_statuses", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		var infos []string
+		for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+			s.polls[id]++
+			switch {
+			case s.invalid[id] != "":
+				infos = append(infos, fmt.Sprintf(invalidJSON, id, s.invalid[id]))
+			case s.pendingPolls < 0 || s.polls[id] <= s.pendingPolls:
+				infos = append(infos, fmt.Sprintf(pendingJSON, id))
+			default:
+				var txns []string
+				for _, txnID := range s.txns[id] {
+					txns = append(txns, fmt.Sprintf(validTxnJSON, txnID))
+				}
+				infos = append(infos, fmt.Sprintf(committedJSON, id, strings.Join(txns, ",")))
+			}
+		}
+		w.Write([]byte("[" + strings.Join(infos, ",") + "]"))
+	})
+
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+func (s *scabbardServer) pollCount(id string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.polls[id]
+}
+
+func newClient(t *testing.T, server *scabbardServer) scabbard.IClient {
+	client, err := scabbard.NewClient(server.URL, CIRCUIT, SERVICE,
+		scabbard.WithPollInterval(time.Millisecond),
+		scabbard.WithStatusWait(0),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestSubmitAndWait(t *testing.T) {
+	server := newScabbardServer(t, 2)
+	defer server.Close()
+	client := newClient(t, server)
+
+	batches := []*transaction_pb2.Batch{testutil.BuildBatch(t, "one"), testutil.BuildBatch(t, "two")}
+	link, err := client.SubmitBatches(context.Background(), batches...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := scabbard.BatchIDsFromLink(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != batches[0].GetHeaderSignature() || ids[1] != batches[1].GetHeaderSignature() {
+		t.Errorf("Unexpected batch IDs in link: %v", ids)
+	}
+
+	infos, err := client.WaitForLink(context.Background(), link, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, info := range infos {
+		if info.Status.StatusType != scabbard.StatusCommitted {
+			t.Errorf("Batch %s is %s", info.ID, info.Status.StatusType)
+		}
+		txnID := batches[i].GetTransactions()[0].GetHeaderSignature()
+		if len(info.Status.Message) != 1 || info.Status.Message[0].TransactionID != txnID ||
+			info.Status.Message[0].ErrorMessage != "" {
+			t.Errorf("Unexpected committed transactions for batch %s: %v", info.ID, info.Status.Message)
+		}
+	}
+	if polls := server.pollCount(ids[0]); polls != 3 {
+		t.Errorf("Expected 3 polls, got %d", polls)
+	}
+}
+
+func TestInvalidTransaction(t *testing.T) {
+	server := newScabbardServer(t, 0)
+	defer server.Close()
+	client := newClient(t, server)
+
+	batch := testutil.BuildBatch(t, "bad")
+	link, err := client.SubmitBatches(context.Background(), batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.WaitForLink(context.Background(), link, time.Second)
+	invalidErr, ok := err.(errors.InvalidTransactionError)
+	if !ok {
+		t.Fatal("Expected InvalidTransactionError, got", err)
+	}
+	if invalidErr.BatchID() != batch.GetHeaderSignature() ||
+		invalidErr.TransactionID() != batch.GetTransactions()[0].GetHeaderSignature() ||
+		invalidErr.Message() != "bad payload" {
+		t.Error("InvalidTransactionError has wrong details:", invalidErr)
+	}
+}
+
+func TestStatusTimeout(t *testing.T) {
+	server := newScabbardServer(t, -1)
+	defer server.Close()
+	client := newClient(t, server)
+
+	batch := testutil.BuildBatch(t, "slow")
+	if _, err := client.SubmitBatches(context.Background(), batch); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := client.WaitForBatches(context.Background(), []string{batch.GetHeaderSignature()}, 20*time.Millisecond)
+	timeoutErr, ok := err.(errors.StatusTimeoutError)
+	if !ok {
+		t.Fatal("Expected StatusTimeoutError, got", err)
+	}
+	if len(timeoutErr.BatchIDs()) != 1 || timeoutErr.BatchIDs()[0] != batch.GetHeaderSignature() {
+		t.Errorf("Unexpected pending batches %v", timeoutErr.BatchIDs())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.WaitForBatches(ctx, []string{batch.GetHeaderSignature()}, 0); err == nil {
+		t.Error("Expected an error from a cancelled context")
+	}
+}
+
+func TestUnexpectedStatusCode(t *testing.T) {
+	server := newScabbardServer(t, 0)
+	defer server.Close()
+
+	client, err := scabbard.NewClient(server.URL, CIRCUIT, "unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.SubmitBatches(context.Background(), testutil.BuildBatch(t, "one"))
+	statusErr, ok := err.(errors.UnexpectedStatusCodeError)
+	if !ok {
+		t.Fatal("Expected UnexpectedStatusCodeError, got", err)
+	}
+	if statusErr.StatusCode() != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", statusErr.StatusCode())
+	}
+
+	if _, err := scabbard.NewClient(server.URL, "", SERVICE); err == nil {
+		t.Error("Expected an error for a missing circuit ID")
+	}
+}
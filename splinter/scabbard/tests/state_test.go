@@ -0,0 +1,156 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/sabre/addressing"
+	"github.com/hyperledger/transact-sdk-go/splinter/scabbard"
+)
+
+const STATEROOT = "3ab7f4c15d9ca2d2ee2a8a3ce4b0b4d5a0b1e8f7d4a4e0c9c1f3d2b1a09e8f7d"
+
+// newStateServer returns an httptest stand-in for a Scabbard service's
+// state endpoints, serving values as JSON arrays of numbers
+func newStateServer(state map[string][]byte) *httptest.Server {
+	numbers := func(value []byte) []int {
+		n := make([]int, len(value))
+		for i, b := range value {
+			n[i] = int(b)
+		}
+		return n
+	}
+
+	base := "/scabbard/" + CIRCUIT + "/" + SERVICE
+	mux := http.NewServeMux()
+	mux.HandleFunc(base+"/state/", func(w http.ResponseWriter, r *http.Request) {
+		value, ok := state[strings.TrimPrefix(r.URL.Path, base+"/state/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not found"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(numbers(value))
+	})
+	mux.HandleFunc(base+"/state", func(w http.ResponseWriter, r *http.Request) {
+		type entry struct {
+			Address string `json:"address"`
+			Value   []int  `json:"value"`
+		}
+		entries := []entry{}
+		for address, value := range state {
+			if strings.HasPrefix(address, r.URL.Query().Get("prefix")) {
+				entries = append(entries, entry{address, numbers(value)})
+			}
+		}
+		json.NewEncoder(w).Encode(entries)
+	})
+	mux.HandleFunc(base+"/state_root", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(STATEROOT)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestState(t *testing.T) {
+	game := addressing.CalculateDeploymentAddress("xo", "game1")
+	other := addressing.CalculateDeploymentAddress("xo", "game2")
+	registry := addressing.ComputeContractRegistryAddress("xo")
+	state := map[string][]byte{
+		game:     []byte("game1,---------,P1-NEXT,,"),
+		other:    {0x00, 0xff, 0x10},
+		registry: {0x01},
+	}
+
+	server := newStateServer(state)
+	defer server.Close()
+	client, err := scabbard.NewClient(server.URL, CIRCUIT, SERVICE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	value, err := client.GetDeploymentState(ctx, "xo", "game1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != "game1,---------,P1-NEXT,," {
+		t.Errorf("Unexpected game state %q", value)
+	}
+
+	value, err = client.GetState(ctx, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != string([]byte{0x00, 0xff, 0x10}) {
+		t.Errorf("Unexpected state %v", value)
+	}
+
+	listed, err := client.ListState(ctx, addressing.ComputeContractPrefix("xo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 2 || string(listed[game]) != string(state[game]) || string(listed[other]) != string(state[other]) {
+		t.Errorf("Unexpected listed state %v", listed)
+	}
+
+	all, err := client.ListState(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 {
+		t.Errorf("Expected 3 entries, got %d", len(all))
+	}
+
+	root, err := client.GetStateRoot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != STATEROOT {
+		t.Errorf("Unexpected state root %s", root)
+	}
+
+	missing := addressing.CalculateDeploymentAddress("xo", "missing")
+	_, err = client.GetState(ctx, missing)
+	notFound, ok := err.(errors.StateNotFoundError)
+	if !ok {
+		t.Fatal("Expected StateNotFoundError, got", err)
+	}
+	if notFound.Address() != missing {
+		t.Errorf("Unexpected address %s", notFound.Address())
+	}
+}
+
+func TestStateOutOfRangeByte(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[1, 256]"))
+	}))
+	defer server.Close()
+
+	client, err := scabbard.NewClient(server.URL, CIRCUIT, SERVICE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetState(context.Background(), "00"); err == nil {
+		t.Error("Expected an error for a value outside a byte")
+	}
+}
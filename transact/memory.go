@@ -0,0 +1,177 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package transact
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+)
+
+// NewMemorySubmitter returns an in-memory Submitter for tests
+func NewMemorySubmitter() *MemorySubmitter {
+	return &MemorySubmitter{
+		statuses: make(map[string]BatchStatus),
+		rejected: make(map[string]string),
+		state:    make(map[string][]byte),
+	}
+}
+
+// MemorySubmitter implements Submitter without a network. Submitted
+// batches are committed immediately, unless they contain a transaction
+// marked with RejectTransaction. Transactions are not executed, so state
+// only changes through SetState and DeleteState.
+type MemorySubmitter struct {
+	mu       sync.Mutex
+	batches  []*transaction_pb2.Batch
+	statuses map[string]BatchStatus
+	rejected map[string]string
+	state    map[string][]byte
+}
+
+// Submit records the batches and commits or rejects them
+func (m *MemorySubmitter) Submit(ctx context.Context, batches ...*transaction_pb2.Batch) ([]string, error) {
+	if len(batches) == 0 {
+		return nil, errors.NewMissingFieldError("Batches")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, batch := range batches {
+		status := BatchStatus{ID: batch.GetHeaderSignature(), Status: StatusCommitted}
+		for _, txn := range batch.GetTransactions() {
+			if message, ok := m.rejected[txn.GetHeaderSignature()]; ok {
+				status.Status = StatusInvalid
+				status.InvalidTransactions = append(status.InvalidTransactions,
+					InvalidTransaction{ID: txn.GetHeaderSignature(), Message: message})
+			}
+		}
+		m.batches = append(m.batches, batch)
+		m.statuses[status.ID] = status
+	}
+	return batchIDs(batches), nil
+}
+
+// GetStatus returns the statuses of the batches. Batches that were never
+// submitted are unknown.
+func (m *MemorySubmitter) GetStatus(ctx context.Context, ids []string) ([]BatchStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	statuses := make([]BatchStatus, len(ids))
+	for i, id := range ids {
+		status, ok := m.statuses[id]
+		if !ok {
+			status = BatchStatus{ID: id, Status: StatusUnknown}
+		}
+		statuses[i] = status
+	}
+	return statuses, nil
+}
+
+// WaitForStatus returns the statuses of the batches. Batches that were
+// never submitted are reported as unknown until the timeout elapses. A
+// timeout of zero waits until ctx is done.
+func (m *MemorySubmitter) WaitForStatus(ctx context.Context, ids []string, timeout time.Duration) ([]BatchStatus, error) {
+	statuses, _ := m.GetStatus(ctx, ids)
+
+	var pending []string
+	for _, status := range statuses {
+		if status.Status == StatusUnknown {
+			pending = append(pending, status.ID)
+		}
+	}
+
+	for _, status := range statuses {
+		if status.Status == StatusInvalid {
+			txn := status.InvalidTransactions[0]
+			return statuses, errors.NewInvalidTransactionError(status.ID, txn.ID, txn.Message)
+		}
+	}
+	if len(pending) > 0 {
+		var expired <-chan time.Time
+		if timeout > 0 {
+			expired = time.After(timeout)
+		}
+		select {
+		case <-ctx.Done():
+			return statuses, ctx.Err()
+		case <-expired:
+		}
+		return statuses, errors.NewStatusTimeoutError(pending)
+	}
+	return statuses, nil
+}
+
+// GetState returns the value at the address, or a StateNotFoundError
+func (m *MemorySubmitter) GetState(ctx context.Context, address string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value, ok := m.state[address]
+	if !ok {
+		return nil, errors.NewStateNotFoundError(address)
+	}
+	return value, nil
+}
+
+// ListState returns the values under the address prefix, keyed by address
+func (m *MemorySubmitter) ListState(ctx context.Context, prefix string) (map[string][]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state := make(map[string][]byte)
+	for address, value := range m.state {
+		if strings.HasPrefix(address, prefix) {
+			state[address] = value
+		}
+	}
+	return state, nil
+}
+
+// SetState stores a value at the address
+func (m *MemorySubmitter) SetState(address string, value []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state[address] = value
+}
+
+// DeleteState removes the value at the address
+func (m *MemorySubmitter) DeleteState(address string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.state, address)
+}
+
+// RejectTransaction makes batches submitted later that contain the
+// transaction invalid, with the given message
+func (m *MemorySubmitter) RejectTransaction(txnID string, message string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rejected[txnID] = message
+}
+
+// GetBatches returns the submitted batches in the order they were submitted
+func (m *MemorySubmitter) GetBatches() []*transaction_pb2.Batch {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*transaction_pb2.Batch{}, m.batches...)
+}
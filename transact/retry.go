@@ -0,0 +1,265 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package transact
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+)
+
+const (
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+)
+
+// NewRetrySubmitter returns a Submitter that retries the given submitter's
+// calls on transient errors, as decided by IsRetryable. Each retry waits
+// an exponentially growing, jittered backoff, and every call stops early
+// when its context is cancelled or its deadline would pass while waiting.
+func NewRetrySubmitter(submitter Submitter, opts ...RetryOption) (Submitter, error) {
+	r := &RetrySubmitter{
+		submitter:      submitter,
+		maxAttempts:    defaultMaxAttempts,
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+	}
+
+	for _, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.maxBackoff < r.initialBackoff {
+		return nil, errors.NewInvalidRetryPolicyError("maximum backoff is less than initial backoff")
+	}
+
+	return r, nil
+}
+
+// RetrySubmitter implements Submitter by retrying another Submitter
+type RetrySubmitter struct {
+	submitter      Submitter
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+func (r *RetrySubmitter) setMaxAttempts(n int)              { r.maxAttempts = n }
+func (r *RetrySubmitter) setInitialBackoff(d time.Duration) { r.initialBackoff = d }
+func (r *RetrySubmitter) setMaxBackoff(d time.Duration)     { r.maxBackoff = d }
+
+// Submit submits the batches, retrying on transient errors. A failed
+// submission may still have reached the network, so before each retry the
+// batch header signatures are used to look up which batches are already
+// known, and only the rest are resubmitted. If the lookup fails with an
+// error that is not transient, as when a backend does not recognize
+// batches it never received, every pending batch is resubmitted.
+func (r *RetrySubmitter) Submit(ctx context.Context, batches ...*transaction_pb2.Batch) ([]string, error) {
+	ids := batchIDs(batches)
+	pending := batches
+
+	for attempt := 1; ; attempt++ {
+		var err error
+		if attempt > 1 {
+			unsubmitted, statusErr := r.unsubmitted(ctx, pending)
+			switch {
+			case statusErr == nil && len(unsubmitted) == 0:
+				return ids, nil
+			case statusErr == nil:
+				pending = unsubmitted
+			case IsRetryable(statusErr):
+				err = statusErr
+			}
+		}
+		if err == nil {
+			if _, err = r.submitter.Submit(ctx, pending...); err == nil {
+				return ids, nil
+			}
+		}
+
+		if !IsRetryable(err) || attempt >= r.maxAttempts {
+			return nil, err
+		}
+		if !r.wait(ctx, attempt) {
+			return nil, err
+		}
+	}
+}
+
+// GetStatus returns the current status of the batches, retrying on
+// transient errors
+func (r *RetrySubmitter) GetStatus(ctx context.Context, ids []string) ([]BatchStatus, error) {
+	var statuses []BatchStatus
+	err := r.retry(ctx, func() (err error) {
+		statuses, err = r.submitter.GetStatus(ctx, ids)
+		return err
+	})
+	return statuses, err
+}
+
+// WaitForStatus waits for the batches to be committed, retrying on
+// transient errors until the timeout elapses
+func (r *RetrySubmitter) WaitForStatus(ctx context.Context, ids []string, timeout time.Duration) ([]BatchStatus, error) {
+	deadline := time.Now().Add(timeout)
+
+	var statuses []BatchStatus
+	err := r.retry(ctx, func() (err error) {
+		remaining := timeout
+		if timeout > 0 {
+			if remaining = time.Until(deadline); remaining <= 0 {
+				return errors.NewStatusTimeoutError(ids)
+			}
+		}
+		statuses, err = r.submitter.WaitForStatus(ctx, ids, remaining)
+		return err
+	})
+	return statuses, err
+}
+
+// GetState returns the value at the address, retrying on transient errors
+func (r *RetrySubmitter) GetState(ctx context.Context, address string) ([]byte, error) {
+	var value []byte
+	err := r.retry(ctx, func() (err error) {
+		value, err = r.submitter.GetState(ctx, address)
+		return err
+	})
+	return value, err
+}
+
+// ListState returns the values under the prefix, retrying on transient errors
+func (r *RetrySubmitter) ListState(ctx context.Context, prefix string) (map[string][]byte, error) {
+	var state map[string][]byte
+	err := r.retry(ctx, func() (err error) {
+		state, err = r.submitter.ListState(ctx, prefix)
+		return err
+	})
+	return state, err
+}
+
+// IsRetryable returns whether an error is transient: a 429 Too Many
+// Requests or 503 Service Unavailable response, or a failed connection.
+// Failed connections are errors dialing or reading from the server,
+// connections refused, reset or closed before a response, and timeouts
+// other than the context's. Errors such as malformed URLs and TLS
+// failures are not transient.
+func IsRetryable(err error) bool {
+	var statusErr errors.UnexpectedStatusCodeError
+	if stderrors.As(err, &statusErr) {
+		return statusErr.StatusCode() == http.StatusTooManyRequests ||
+			statusErr.StatusCode() == http.StatusServiceUnavailable
+	}
+	if err == nil || isContextError(err) {
+		return false
+	}
+
+	if stderrors.Is(err, syscall.ECONNREFUSED) || stderrors.Is(err, syscall.ECONNRESET) ||
+		stderrors.Is(err, io.EOF) || stderrors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var opErr *net.OpError
+	if stderrors.As(err, &opErr) && (opErr.Op == "dial" || opErr.Op == "read") {
+		return true
+	}
+	var netErr net.Error
+	return stderrors.As(err, &netErr) && netErr.Timeout()
+}
+
+// ---
+
+func (r *RetrySubmitter) retry(ctx context.Context, call func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := call()
+		if err == nil || !IsRetryable(err) || attempt >= r.maxAttempts {
+			return err
+		}
+		if !r.wait(ctx, attempt) {
+			return err
+		}
+	}
+}
+
+// unsubmitted returns the batches the network does not know about
+func (r *RetrySubmitter) unsubmitted(ctx context.Context, batches []*transaction_pb2.Batch) ([]*transaction_pb2.Batch, error) {
+	statuses, err := r.submitter.GetStatus(ctx, batchIDs(batches))
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool)
+	for _, status := range statuses {
+		if status.Status != StatusUnknown {
+			known[status.ID] = true
+		}
+	}
+
+	var unsubmitted []*transaction_pb2.Batch
+	for _, batch := range batches {
+		if !known[batch.GetHeaderSignature()] {
+			unsubmitted = append(unsubmitted, batch)
+		}
+	}
+	return unsubmitted, nil
+}
+
+// wait sleeps for the backoff before the given attempt's retry. It returns
+// false without sleeping out the backoff if the context is cancelled, or
+// if its deadline would pass first.
+func (r *RetrySubmitter) wait(ctx context.Context, attempt int) bool {
+	delay := r.backoff(attempt)
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return false
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// backoff doubles the initial backoff for each attempt up to the maximum,
+// then picks a random delay between half of it and all of it so that
+// clients that failed together do not retry together
+func (r *RetrySubmitter) backoff(attempt int) time.Duration {
+	backoff := r.initialBackoff
+	for i := 1; i < attempt && backoff < r.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > r.maxBackoff {
+		backoff = r.maxBackoff
+	}
+
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
+
+func isContextError(err error) bool {
+	return stderrors.Is(err, context.Canceled) || stderrors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,51 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package transact
+
+import (
+	"time"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+)
+
+// RetryOption provides the functional options for creating a RetrySubmitter
+type RetryOption func(*RetrySubmitter) error
+
+// WithMaxAttempts sets the Retry Option for how many times each call is
+// attempted, including the first. Defaults to 5.
+func WithMaxAttempts(n int) RetryOption {
+	return func(r *RetrySubmitter) error {
+		if n < 1 {
+			return errors.NewInvalidRetryPolicyError("maximum attempts must be at least one")
+		}
+		r.setMaxAttempts(n)
+		return nil
+	}
+}
+
+// WithBackoff sets the Retry Option for the backoff before the first
+// retry, which doubles for each later retry up to max. Defaults to half a
+// second, doubling up to ten seconds.
+func WithBackoff(initial time.Duration, max time.Duration) RetryOption {
+	return func(r *RetrySubmitter) error {
+		if initial <= 0 {
+			return errors.NewInvalidRetryPolicyError("initial backoff must be greater than zero")
+		}
+		r.setInitialBackoff(initial)
+		r.setMaxBackoff(max)
+		return nil
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package transact
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/transact-sdk-go/sawtooth/rest"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+)
+
+// NewSawtoothSubmitter returns a Submitter for a Sawtooth REST API
+func NewSawtoothSubmitter(client rest.IClient) Submitter {
+	return &SawtoothSubmitter{client}
+}
+
+// SawtoothSubmitter implements Submitter with a Sawtooth REST API client
+type SawtoothSubmitter struct {
+	client rest.IClient
+}
+
+// Submit submits the batches to the validator
+func (s *SawtoothSubmitter) Submit(ctx context.Context, batches ...*transaction_pb2.Batch) ([]string, error) {
+	if _, err := s.client.SubmitBatches(ctx, batches...); err != nil {
+		return nil, err
+	}
+	return batchIDs(batches), nil
+}
+
+// GetStatus returns the current status of the batches
+func (s *SawtoothSubmitter) GetStatus(ctx context.Context, ids []string) ([]BatchStatus, error) {
+	restStatuses, err := s.client.GetBatchStatuses(ctx, ids, 0)
+	return sawtoothStatuses(restStatuses), err
+}
+
+// WaitForStatus waits for the batches to be committed to the chain
+func (s *SawtoothSubmitter) WaitForStatus(ctx context.Context, ids []string, timeout time.Duration) ([]BatchStatus, error) {
+	restStatuses, err := s.client.WaitForBatches(ctx, ids, timeout)
+	return sawtoothStatuses(restStatuses), err
+}
+
+// GetState returns the value at the address in the current global state
+func (s *SawtoothSubmitter) GetState(ctx context.Context, address string) ([]byte, error) {
+	return s.client.GetState(ctx, address)
+}
+
+// ListState returns the values under the prefix in the current global state
+func (s *SawtoothSubmitter) ListState(ctx context.Context, prefix string) (map[string][]byte, error) {
+	return s.client.ListState(ctx, prefix)
+}
+
+func sawtoothStatuses(restStatuses []rest.BatchStatus) []BatchStatus {
+	statuses := make([]BatchStatus, len(restStatuses))
+	for i, status := range restStatuses {
+		// The REST API's status values are the common ones
+		statuses[i] = BatchStatus{ID: status.ID, Status: status.Status}
+		for _, txn := range status.InvalidTransactions {
+			statuses[i].InvalidTransactions = append(statuses[i].InvalidTransactions,
+				InvalidTransaction{ID: txn.ID, Message: txn.Message})
+		}
+	}
+	return statuses
+}
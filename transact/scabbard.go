@@ -0,0 +1,95 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package transact
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/transact-sdk-go/splinter/scabbard"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+)
+
+// NewScabbardSubmitter returns a Submitter for a Splinter Scabbard service
+func NewScabbardSubmitter(client scabbard.IClient) Submitter {
+	return &ScabbardSubmitter{client}
+}
+
+// ScabbardSubmitter implements Submitter with a Scabbard client
+type ScabbardSubmitter struct {
+	client scabbard.IClient
+}
+
+// Submit submits the batches to the Scabbard service
+func (s *ScabbardSubmitter) Submit(ctx context.Context, batches ...*transaction_pb2.Batch) ([]string, error) {
+	if _, err := s.client.SubmitBatches(ctx, batches...); err != nil {
+		return nil, err
+	}
+	return batchIDs(batches), nil
+}
+
+// GetStatus returns the current status of the batches in the Scabbard service
+func (s *ScabbardSubmitter) GetStatus(ctx context.Context, ids []string) ([]BatchStatus, error) {
+	infos, err := s.client.GetBatchStatuses(ctx, ids, 0)
+	return scabbardStatuses(infos), err
+}
+
+// WaitForStatus waits for the batches to be committed by the Scabbard service
+func (s *ScabbardSubmitter) WaitForStatus(ctx context.Context, ids []string, timeout time.Duration) ([]BatchStatus, error) {
+	infos, err := s.client.WaitForBatches(ctx, ids, timeout)
+	return scabbardStatuses(infos), err
+}
+
+// GetState returns the value at the address in the Scabbard service's state
+func (s *ScabbardSubmitter) GetState(ctx context.Context, address string) ([]byte, error) {
+	return s.client.GetState(ctx, address)
+}
+
+// ListState returns the values under the prefix in the Scabbard service's state
+func (s *ScabbardSubmitter) ListState(ctx context.Context, prefix string) (map[string][]byte, error) {
+	return s.client.ListState(ctx, prefix)
+}
+
+func scabbardStatuses(infos []scabbard.BatchInfo) []BatchStatus {
+	statuses := make([]BatchStatus, len(infos))
+	for i, info := range infos {
+		statuses[i] = BatchStatus{ID: info.ID, Status: scabbardStatus(info.Status.StatusType)}
+		// Scabbard also lists the transactions of valid and committed
+		// batches, which carry no error
+		if info.Status.StatusType != scabbard.StatusInvalid {
+			continue
+		}
+		for _, txn := range info.Status.Message {
+			statuses[i].InvalidTransactions = append(statuses[i].InvalidTransactions,
+				InvalidTransaction{ID: txn.TransactionID, Message: txn.ErrorMessage})
+		}
+	}
+	return statuses
+}
+
+// scabbardStatus maps a Scabbard status type to a common status. Batches
+// that Scabbard has found valid but not yet committed are pending.
+func scabbardStatus(statusType string) string {
+	switch statusType {
+	case scabbard.StatusCommitted:
+		return StatusCommitted
+	case scabbard.StatusInvalid:
+		return StatusInvalid
+	case scabbard.StatusPending, scabbard.StatusValid:
+		return StatusPending
+	}
+	return StatusUnknown
+}
@@ -0,0 +1,130 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package transact
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/sawtooth/rest"
+	"github.com/hyperledger/transact-sdk-go/splinter/scabbard"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+)
+
+// Names of the backends NewSubmitter can create
+const (
+	BackendScabbard = "scabbard"
+	BackendSawtooth = "sawtooth"
+	BackendMemory   = "memory"
+)
+
+// Batch status values shared by every backend
+const (
+	StatusCommitted = "COMMITTED"
+	StatusInvalid   = "INVALID"
+	StatusPending   = "PENDING"
+	StatusUnknown   = "UNKNOWN"
+)
+
+// BatchStatus is the status of a submitted batch
+type BatchStatus struct {
+	ID                  string
+	Status              string
+	InvalidTransactions []InvalidTransaction
+}
+
+// InvalidTransaction describes a transaction that was rejected
+type InvalidTransaction struct {
+	ID      string
+	Message string
+}
+
+// Submitter defines the interface for submitting batches and reading
+// state, independent of the network the batches are sent to
+type Submitter interface {
+	// Submits the batches in a single batch list and returns their IDs.
+	Submit(ctx context.Context, batches ...*transaction_pb2.Batch) ([]string, error)
+
+	// Returns the current status of each batch without waiting.
+	GetStatus(ctx context.Context, ids []string) ([]BatchStatus, error)
+
+	// Waits until every batch is committed and returns their statuses.
+	// Returns an InvalidTransactionError if a batch is rejected, and a
+	// StatusTimeoutError if the batches have not completed within timeout.
+	// A timeout of zero waits until ctx is done.
+	WaitForStatus(ctx context.Context, ids []string, timeout time.Duration) ([]BatchStatus, error)
+
+	// Returns the value at the address, or a StateNotFoundError.
+	GetState(ctx context.Context, address string) ([]byte, error)
+
+	// Returns the values under the address prefix, keyed by address.
+	ListState(ctx context.Context, prefix string) (map[string][]byte, error)
+}
+
+// Config selects and configures a Submitter backend
+type Config struct {
+	// One of BackendScabbard, BackendSawtooth or BackendMemory.
+	Backend string
+
+	// The Splinter or Sawtooth REST API URL, such as "http://localhost:8088".
+	URL string
+
+	// The Scabbard service's circuit and service IDs.
+	CircuitID string
+	ServiceID string
+
+	// The http.Client used to make requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// NewSubmitter returns the Submitter for the configured backend, or an
+// UnknownBackendError
+func NewSubmitter(config Config) (Submitter, error) {
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	switch config.Backend {
+	case BackendScabbard:
+		client, err := scabbard.NewClient(config.URL, config.CircuitID, config.ServiceID,
+			scabbard.WithHTTPClient(httpClient))
+		if err != nil {
+			return nil, err
+		}
+		return NewScabbardSubmitter(client), nil
+	case BackendSawtooth:
+		client, err := rest.NewClient(config.URL, rest.WithHTTPClient(httpClient))
+		if err != nil {
+			return nil, err
+		}
+		return NewSawtoothSubmitter(client), nil
+	case BackendMemory:
+		return NewMemorySubmitter(), nil
+	}
+
+	return nil, errors.NewUnknownBackendError(config.Backend)
+}
+
+func batchIDs(batches []*transaction_pb2.Batch) []string {
+	ids := make([]string, len(batches))
+	for i, batch := range batches {
+		ids[i] = batch.GetHeaderSignature()
+	}
+	return ids
+}
@@ -0,0 +1,405 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/internal/testutil"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transact"
+)
+
+// flakySubmitter fails the first failures calls to Submit with err. The
+// failing calls still deliver their first delivered batches to the memory
+// submitter, as when a response is lost after some batches were accepted.
+// GetStatus fails with statusErr when it is set.
+type flakySubmitter struct {
+	*transact.MemorySubmitter
+
+	mu        sync.Mutex
+	failures  int
+	err       error
+	delivered int
+	statusErr error
+	sizes     []int
+}
+
+func (f *flakySubmitter) Submit(ctx context.Context, batches ...*transaction_pb2.Batch) ([]string, error) {
+	f.mu.Lock()
+	f.sizes = append(f.sizes, len(batches))
+	fail := len(f.sizes) <= f.failures
+	f.mu.Unlock()
+
+	if !fail {
+		return f.MemorySubmitter.Submit(ctx, batches...)
+	}
+	if f.delivered > 0 {
+		delivered := batches
+		if f.delivered < len(batches) {
+			delivered = batches[:f.delivered]
+		}
+		if _, err := f.MemorySubmitter.Submit(ctx, delivered...); err != nil {
+			return nil, err
+		}
+	}
+	return nil, f.err
+}
+
+func (f *flakySubmitter) GetStatus(ctx context.Context, ids []string) ([]transact.BatchStatus, error) {
+	if f.statusErr != nil {
+		return nil, f.statusErr
+	}
+	return f.MemorySubmitter.GetStatus(ctx, ids)
+}
+
+func (f *flakySubmitter) calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.sizes)
+}
+
+func newRetrySubmitter(t *testing.T, inner transact.Submitter, opts ...transact.RetryOption) transact.Submitter {
+	opts = append([]transact.RetryOption{transact.WithBackoff(time.Millisecond, 4*time.Millisecond)}, opts...)
+	submitter, err := transact.NewRetrySubmitter(inner, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return submitter
+}
+
+func TestRetryUnavailable(t *testing.T) {
+	flaky := &flakySubmitter{
+		MemorySubmitter: transact.NewMemorySubmitter(),
+		failures:        2,
+		err:             errors.NewUnexpectedStatusCodeError(http.StatusServiceUnavailable, ""),
+	}
+	submitter := newRetrySubmitter(t, flaky)
+
+	batch := testutil.BuildBatch(t, "one")
+	ids, err := submitter.Submit(context.Background(), batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != batch.GetHeaderSignature() {
+		t.Errorf("Unexpected batch IDs: %v", ids)
+	}
+	if flaky.calls() != 3 {
+		t.Errorf("Expected 3 submissions, got %d", flaky.calls())
+	}
+}
+
+func TestRetryDoesNotResubmit(t *testing.T) {
+	flaky := &flakySubmitter{
+		MemorySubmitter: transact.NewMemorySubmitter(),
+		failures:        1,
+		err:             errors.NewUnexpectedStatusCodeError(http.StatusTooManyRequests, ""),
+		delivered:       1,
+	}
+	submitter := newRetrySubmitter(t, flaky)
+
+	if _, err := submitter.Submit(context.Background(), testutil.BuildBatch(t, "one")); err != nil {
+		t.Fatal(err)
+	}
+	if flaky.calls() != 1 {
+		t.Errorf("Expected 1 submission, got %d", flaky.calls())
+	}
+	if batches := flaky.GetBatches(); len(batches) != 1 {
+		t.Errorf("Expected 1 recorded batch, got %d", len(batches))
+	}
+}
+
+func TestRetryPartialResubmission(t *testing.T) {
+	flaky := &flakySubmitter{
+		MemorySubmitter: transact.NewMemorySubmitter(),
+		failures:        1,
+		err:             errors.NewUnexpectedStatusCodeError(http.StatusServiceUnavailable, ""),
+		delivered:       1,
+	}
+	submitter := newRetrySubmitter(t, flaky)
+
+	batches := []*transaction_pb2.Batch{testutil.BuildBatch(t, "one"), testutil.BuildBatch(t, "two"), testutil.BuildBatch(t, "three")}
+	ids, err := submitter.Submit(context.Background(), batches...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 {
+		t.Errorf("Expected 3 batch IDs, got %v", ids)
+	}
+	if len(flaky.sizes) != 2 || flaky.sizes[1] != 2 {
+		t.Errorf("Expected the retry to resubmit 2 batches, got submissions of %v", flaky.sizes)
+	}
+
+	recorded := flaky.GetBatches()
+	if len(recorded) != 3 {
+		t.Fatalf("Expected 3 recorded batches, got %d", len(recorded))
+	}
+	for i, batch := range recorded {
+		if batch.GetHeaderSignature() != batches[i].GetHeaderSignature() {
+			t.Errorf("Recorded batch %d is %s", i, batch.GetHeaderSignature())
+		}
+	}
+}
+
+func TestRetryStatusLookupFails(t *testing.T) {
+	flaky := &flakySubmitter{
+		MemorySubmitter: transact.NewMemorySubmitter(),
+		failures:        1,
+		err:             errors.NewUnexpectedStatusCodeError(http.StatusServiceUnavailable, ""),
+		statusErr:       errors.NewUnexpectedStatusCodeError(http.StatusNotFound, "unknown batch"),
+	}
+	submitter := newRetrySubmitter(t, flaky)
+
+	batch := testutil.BuildBatch(t, "one")
+	if _, err := submitter.Submit(context.Background(), batch); err != nil {
+		t.Fatal(err)
+	}
+	if flaky.calls() != 2 {
+		t.Errorf("Expected the batch to be resubmitted, got %d submissions", flaky.calls())
+	}
+	if batches := flaky.GetBatches(); len(batches) != 1 {
+		t.Errorf("Expected 1 recorded batch, got %d", len(batches))
+	}
+}
+
+func TestRetryConnectionError(t *testing.T) {
+	backend := newBackendServer(t)
+	defer backend.Close()
+
+	// Drop the connection of the first submission before responding
+	var mu sync.Mutex
+	dropped := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		drop := !dropped && r.URL.Path == "/batches"
+		dropped = dropped || drop
+		mu.Unlock()
+
+		if drop {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		backend.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	inner, err := transact.NewSubmitter(transact.Config{Backend: transact.BackendSawtooth, URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	submitter := newRetrySubmitter(t, inner)
+
+	batch := testutil.BuildBatch(t, "one")
+	ids, err := submitter.Submit(context.Background(), batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dropped {
+		t.Error("Expected the first submission's connection to be dropped")
+	}
+
+	statuses, err := submitter.WaitForStatus(context.Background(), ids, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statuses[0].Status != transact.StatusCommitted {
+		t.Errorf("Expected %s, got %s", transact.StatusCommitted, statuses[0].Status)
+	}
+}
+
+func TestRetryNotRetryable(t *testing.T) {
+	flaky := &flakySubmitter{
+		MemorySubmitter: transact.NewMemorySubmitter(),
+		failures:        1,
+		err:             errors.NewUnexpectedStatusCodeError(http.StatusBadRequest, "invalid batch list"),
+	}
+	submitter := newRetrySubmitter(t, flaky)
+
+	_, err := submitter.Submit(context.Background(), testutil.BuildBatch(t, "one"))
+	if statusErr, ok := err.(errors.UnexpectedStatusCodeError); !ok || statusErr.StatusCode() != http.StatusBadRequest {
+		t.Errorf("Expected a 400 UnexpectedStatusCodeError, got %v", err)
+	}
+	if flaky.calls() != 1 {
+		t.Errorf("Expected 1 submission, got %d", flaky.calls())
+	}
+}
+
+func TestRetryAttemptsExhausted(t *testing.T) {
+	flaky := &flakySubmitter{
+		MemorySubmitter: transact.NewMemorySubmitter(),
+		failures:        10,
+		err:             errors.NewUnexpectedStatusCodeError(http.StatusServiceUnavailable, ""),
+	}
+	submitter := newRetrySubmitter(t, flaky, transact.WithMaxAttempts(3))
+
+	if _, err := submitter.Submit(context.Background(), testutil.BuildBatch(t, "one")); err == nil {
+		t.Error("Expected an error")
+	}
+	if flaky.calls() != 3 {
+		t.Errorf("Expected 3 submissions, got %d", flaky.calls())
+	}
+}
+
+func TestRetryCancelled(t *testing.T) {
+	flaky := &flakySubmitter{
+		MemorySubmitter: transact.NewMemorySubmitter(),
+		failures:        10,
+		err:             errors.NewUnexpectedStatusCodeError(http.StatusServiceUnavailable, ""),
+	}
+	submitter, err := transact.NewRetrySubmitter(flaky, transact.WithBackoff(time.Minute, time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	start := time.Now()
+	if _, err := submitter.Submit(ctx, testutil.BuildBatch(t, "one")); err == nil {
+		t.Error("Expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Cancellation took %s", elapsed)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	submitter, err := transact.NewSubmitter(transact.Config{Backend: transact.BackendSawtooth, URL: url})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = submitter.GetState(context.Background(), "1cf126")
+	if !transact.IsRetryable(err) {
+		t.Errorf("Expected connection error to be retryable: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = submitter.GetState(ctx, "1cf126")
+	if transact.IsRetryable(err) {
+		t.Errorf("Expected cancellation not to be retryable: %v", err)
+	}
+
+	if transact.IsRetryable(errors.NewStateNotFoundError("1cf126")) {
+		t.Error("Expected StateNotFoundError not to be retryable")
+	}
+}
+
+func TestIsRetryableBadURL(t *testing.T) {
+	for _, rawURL := range []string{"http://%zz", "ftp://localhost"} {
+		submitter, err := transact.NewSubmitter(transact.Config{Backend: transact.BackendSawtooth, URL: rawURL})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = submitter.GetState(context.Background(), "1cf126")
+		if err == nil {
+			t.Fatalf("Expected an error for %s", rawURL)
+		}
+		if transact.IsRetryable(err) {
+			t.Errorf("Expected bad URL %s not to be retryable: %v", rawURL, err)
+		}
+	}
+}
+
+func TestIsRetryableTLSError(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	// The default client does not trust the test server's certificate
+	submitter, err := transact.NewSubmitter(transact.Config{Backend: transact.BackendSawtooth, URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = submitter.GetState(context.Background(), "1cf126")
+	if err == nil {
+		t.Fatal("Expected a certificate error")
+	}
+	if transact.IsRetryable(err) {
+		t.Errorf("Expected TLS error not to be retryable: %v", err)
+	}
+}
+
+func TestIsRetryableWrappedErrors(t *testing.T) {
+	wrap := func(err error) error {
+		return &url.Error{Op: "Post", URL: "http://localhost:8008/batches", Err: err}
+	}
+	retryable := []error{
+		wrap(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}),
+		wrap(&net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}),
+		wrap(io.EOF),
+		fmt.Errorf("status: %w", errors.NewUnexpectedStatusCodeError(http.StatusServiceUnavailable, "")),
+	}
+	for _, err := range retryable {
+		if !transact.IsRetryable(err) {
+			t.Errorf("Expected %v to be retryable", err)
+		}
+	}
+
+	notRetryable := []error{
+		nil,
+		wrap(context.Canceled),
+		wrap(context.DeadlineExceeded),
+		wrap(fmt.Errorf("dial: %w", context.DeadlineExceeded)),
+		wrap(stderrors.New("unsupported protocol scheme")),
+		wrap(&net.OpError{Op: "remote error", Net: "tcp", Err: stderrors.New("tls: bad certificate")}),
+	}
+	for _, err := range notRetryable {
+		if transact.IsRetryable(err) {
+			t.Errorf("Expected %v not to be retryable", err)
+		}
+	}
+}
+
+func TestInvalidRetryPolicy(t *testing.T) {
+	memory := transact.NewMemorySubmitter()
+	for _, opt := range []transact.RetryOption{
+		transact.WithMaxAttempts(0),
+		transact.WithBackoff(0, time.Second),
+		transact.WithBackoff(time.Second, time.Millisecond),
+	} {
+		if _, err := transact.NewRetrySubmitter(memory, opt); err == nil {
+			t.Error("Expected InvalidRetryPolicyError")
+		} else if _, ok := err.(errors.InvalidRetryPolicyError); !ok {
+			t.Errorf("Expected InvalidRetryPolicyError, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/internal/testutil"
+	"github.com/hyperledger/transact-sdk-go/sawtooth/rest"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transact"
+)
+
+const (
+	CIRCUIT = "01234-ABCDE"
+	SERVICE = "a000"
+)
+
+// Scabbard batch_statuses entries, as Scabbard encodes them. Committed
+// and valid batches list their transactions without an error.
+const (
+	scabbardCommitted = `{"id":"%s","status":{"statusType":"Committed","message":[%s]},"timestamp":{"secs_since_epoch":1602000000,"nanos_since_epoch":0}}`
+	scabbardPending   = `{"id":"%s","status":{"statusType":"Pending","message":[]},"timestamp":{"secs_since_epoch":1602000000,"nanos_since_epoch":0}}`
+	scabbardInvalid   = `{"id":"%s","status":{"statusType":"Invalid","message":[{"transaction_id":"%s","error_message":"bad payload","error_data":[]}]},"timestamp":{"secs_since_epoch":1602000000,"nanos_since_epoch":0}}`
+	scabbardUnknown   = `{"id":"%s","status":{"statusType":"Unknown","message":[]},"timestamp":{"secs_since_epoch":1602000000,"nanos_since_epoch":0}}`
+	scabbardValidTxn  = `{"transaction_id":"%s"}`
+)
+
+// backendBatch is a batch received by the backend server
+type backendBatch struct {
+	txnIDs  []string
+	payload string
+}
+
+// newBackendServer is an httptest stand-in for both a Scabbard service and
+// a Sawtooth REST API. Every submitted batch is committed immediately,
+// except that a batch whose first transaction's payload is "bad" is
+// invalid and one whose payload is "pending" stays pending.
+func newBackendServer(t *testing.T) *httptest.Server {
+	var mu sync.Mutex
+	received := make(map[string]backendBatch)
+
+	submit := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		batchList := &transaction_pb2.BatchList{}
+		if err := proto.Unmarshal(body, batchList); err != nil {
+			t.Error(err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		var ids []string
+		for _, batch := range batchList.GetBatches() {
+			var info backendBatch
+			for _, txn := range batch.GetTransactions() {
+				info.txnIDs = append(info.txnIDs, txn.GetHeaderSignature())
+			}
+			info.payload = string(batch.GetTransactions()[0].GetPayload())
+			received[batch.GetHeaderSignature()] = info
+			ids = append(ids, batch.GetHeaderSignature())
+		}
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]string{
+			"link": r.URL.Path + "?ids=" + strings.Join(ids, ","),
+		})
+	}
+	lookup := func(id string) (backendBatch, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		batch, ok := received[id]
+		return batch, ok
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/scabbard/"+CIRCUIT+"/"+SERVICE+"/batches", submit)
+	mux.HandleFunc("/scabbard/"+CIRCUIT+"/"+SERVICE+"/batch_statuses", func(w http.ResponseWriter, r *http.Request) {
+		var infos []string
+		for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+			batch, ok := lookup(id)
+			switch {
+			case !ok:
+				infos = append(infos, fmt.Sprintf(scabbardUnknown, id))
+			case batch.payload == "bad":
+				infos = append(infos, fmt.Sprintf(scabbardInvalid, id, batch.txnIDs[0]))
+			case batch.payload == "pending":
+				infos = append(infos, fmt.Sprintf(scabbardPending, id))
+			default:
+				var txns []string
+				for _, txnID := range batch.txnIDs {
+					txns = append(txns, fmt.Sprintf(scabbardValidTxn, txnID))
+				}
+				infos = append(infos, fmt.Sprintf(scabbardCommitted, id, strings.Join(txns, ",")))
+			}
+		}
+		w.Write([]byte("[" + strings.Join(infos, ",") + "]"))
+	})
+	mux.HandleFunc("/batches", submit)
+	mux.HandleFunc("/batch_statuses", func(w http.ResponseWriter, r *http.Request) {
+		var statuses []rest.BatchStatus
+		for _, id := range strings.Split(r.URL.Query().Get("id"), ",") {
+			status := rest.BatchStatus{ID: id, Status: "COMMITTED"}
+			batch, ok := lookup(id)
+			switch {
+			case !ok:
+				status.Status = "UNKNOWN"
+			case batch.payload == "bad":
+				status.Status = "INVALID"
+				status.InvalidTransactions = []rest.InvalidTransaction{{ID: batch.txnIDs[0], Message: "bad payload"}}
+			case batch.payload == "pending":
+				status.Status = "PENDING"
+			}
+			statuses = append(statuses, status)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": statuses})
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestSubmitters(t *testing.T) {
+	server := newBackendServer(t)
+	defer server.Close()
+
+	for _, backend := range []string{transact.BackendScabbard, transact.BackendSawtooth, transact.BackendMemory} {
+		submitter, err := transact.NewSubmitter(transact.Config{
+			Backend:   backend,
+			URL:       server.URL,
+			CircuitID: CIRCUIT,
+			ServiceID: SERVICE,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		batches := []*transaction_pb2.Batch{testutil.BuildBatch(t, "one"), testutil.BuildBatch(t, "two")}
+		ids, err := submitter.Submit(context.Background(), batches...)
+		if err != nil {
+			t.Fatalf("%s: %v", backend, err)
+		}
+		if len(ids) != 2 || ids[0] != batches[0].GetHeaderSignature() || ids[1] != batches[1].GetHeaderSignature() {
+			t.Errorf("%s: unexpected batch IDs: %v", backend, ids)
+		}
+
+		statuses, err := submitter.WaitForStatus(context.Background(), ids, time.Second)
+		if err != nil {
+			t.Fatalf("%s: %v", backend, err)
+		}
+		for i, status := range statuses {
+			if status.ID != ids[i] || status.Status != transact.StatusCommitted {
+				t.Errorf("%s: batch %s is %s", backend, status.ID, status.Status)
+			}
+			if len(status.InvalidTransactions) != 0 {
+				t.Errorf("%s: committed batch %s has invalid transactions: %v",
+					backend, status.ID, status.InvalidTransactions)
+			}
+		}
+	}
+}
+
+func TestSubmitterStatuses(t *testing.T) {
+	server := newBackendServer(t)
+	defer server.Close()
+
+	for _, backend := range []string{transact.BackendScabbard, transact.BackendSawtooth} {
+		submitter, err := transact.NewSubmitter(transact.Config{
+			Backend:   backend,
+			URL:       server.URL,
+			CircuitID: CIRCUIT,
+			ServiceID: SERVICE,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		batches := []*transaction_pb2.Batch{testutil.BuildBatch(t, "one"), testutil.BuildBatch(t, "pending"), testutil.BuildBatch(t, "bad")}
+		ids, err := submitter.Submit(context.Background(), batches...)
+		if err != nil {
+			t.Fatalf("%s: %v", backend, err)
+		}
+
+		statuses, err := submitter.GetStatus(context.Background(), append(ids, "missing"))
+		if err != nil {
+			t.Fatalf("%s: %v", backend, err)
+		}
+		if len(statuses) != 4 {
+			t.Fatalf("%s: expected 4 statuses, got %v", backend, statuses)
+		}
+
+		expected := []string{transact.StatusCommitted, transact.StatusPending, transact.StatusInvalid, transact.StatusUnknown}
+		for i, status := range statuses {
+			if status.Status != expected[i] {
+				t.Errorf("%s: expected batch %d to be %s, got %s", backend, i, expected[i], status.Status)
+			}
+			if i != 2 && len(status.InvalidTransactions) != 0 {
+				t.Errorf("%s: %s batch has invalid transactions: %v",
+					backend, status.Status, status.InvalidTransactions)
+			}
+		}
+
+		invalid := statuses[2].InvalidTransactions
+		txnID := batches[2].GetTransactions()[0].GetHeaderSignature()
+		if len(invalid) != 1 || invalid[0].ID != txnID || invalid[0].Message != "bad payload" {
+			t.Errorf("%s: unexpected invalid transactions: %v", backend, invalid)
+		}
+	}
+}
+
+func TestUnknownBackend(t *testing.T) {
+	_, err := transact.NewSubmitter(transact.Config{Backend: "fabric"})
+	if _, ok := err.(errors.UnknownBackendError); !ok {
+		t.Errorf("Expected UnknownBackendError, got %v", err)
+	}
+}
+
+func TestMemoryRejectTransaction(t *testing.T) {
+	submitter := transact.NewMemorySubmitter()
+	batch := testutil.BuildBatch(t, "bad")
+	txnID := batch.GetTransactions()[0].GetHeaderSignature()
+	submitter.RejectTransaction(txnID, "bad payload")
+
+	ids, err := submitter.Submit(context.Background(), batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses, err := submitter.WaitForStatus(context.Background(), ids, time.Second)
+	invalidErr, ok := err.(errors.InvalidTransactionError)
+	if !ok {
+		t.Fatalf("Expected InvalidTransactionError, got %v", err)
+	}
+	if invalidErr.TransactionID() != txnID || invalidErr.Message() != "bad payload" {
+		t.Errorf("Unexpected error: %v", invalidErr)
+	}
+	if statuses[0].Status != transact.StatusInvalid {
+		t.Errorf("Expected %s, got %s", transact.StatusInvalid, statuses[0].Status)
+	}
+	if len(submitter.GetBatches()) != 1 {
+		t.Errorf("Expected 1 recorded batch, got %d", len(submitter.GetBatches()))
+	}
+}
+
+func TestMemoryUnknownBatch(t *testing.T) {
+	submitter := transact.NewMemorySubmitter()
+
+	_, err := submitter.WaitForStatus(context.Background(), []string{"missing"}, time.Millisecond)
+	timeoutErr, ok := err.(errors.StatusTimeoutError)
+	if !ok {
+		t.Fatalf("Expected StatusTimeoutError, got %v", err)
+	}
+	if ids := timeoutErr.BatchIDs(); len(ids) != 1 || ids[0] != "missing" {
+		t.Errorf("Unexpected batch IDs: %v", ids)
+	}
+}
+
+func TestMemoryWaitWithoutTimeout(t *testing.T) {
+	submitter := transact.NewMemorySubmitter()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := submitter.WaitForStatus(ctx, []string{"missing"}, 0)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected to wait until the context expired, got %v", err)
+	}
+}
+
+func TestMemoryState(t *testing.T) {
+	submitter := transact.NewMemorySubmitter()
+	submitter.SetState("1cf12601", []byte("one"))
+	submitter.SetState("1cf12602", []byte("two"))
+	submitter.SetState("abcdef01", []byte("other"))
+
+	value, err := submitter.GetState(context.Background(), "1cf12601")
+	if err != nil || string(value) != "one" {
+		t.Errorf("Unexpected state: %q, %v", value, err)
+	}
+
+	state, err := submitter.ListState(context.Background(), "1cf126")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state) != 2 || string(state["1cf12602"]) != "two" {
+		t.Errorf("Unexpected state: %v", state)
+	}
+
+	submitter.DeleteState("1cf12601")
+	if _, err := submitter.GetState(context.Background(), "1cf12601"); err == nil {
+		t.Error("Expected StateNotFoundError")
+	} else if _, ok := err.(errors.StateNotFoundError); !ok {
+		t.Errorf("Expected StateNotFoundError, got %v", err)
+	}
+}
@@ -29,7 +29,8 @@ import (
 type IBatchBuilder interface {
 	GetTransactions() []*transaction_pb2.Transaction
 	GetTrace() bool
-	Build(signer *signing.Signer) ([]byte, error)
+	BuildBatch(signer signing.ISigner) (*transaction_pb2.Batch, error)
+	Build(signer signing.ISigner) ([]byte, error)
 }
 
 // NewBatchBuilder returns a new instance of IBatchBuilder interface
@@ -64,19 +65,28 @@ func (b *BatchBuilder) GetTrace() bool {
 func (b *BatchBuilder) setTransactions(t []*transaction_pb2.Transaction) { b.transactions = t }
 func (b *BatchBuilder) setTrace(t bool)                                  { b.trace = t }
 
-func (b *BatchBuilder) buildHeader(signer *signing.Signer) ([]byte, error) {
+func (b *BatchBuilder) buildHeader(signer signing.ISigner) ([]byte, error) {
 	if len(b.transactions) == 0 {
 		return nil, errors.NewMissingFieldError("Transactions")
 	}
 
+	signerPublicKey := signer.GetPublicKey().AsHex()
 	transactionIds := make([]string, len(b.transactions))
 
 	for i, txn := range b.transactions {
+		txnHeader := &transaction_pb2.TransactionHeader{}
+		if err := proto.Unmarshal(txn.GetHeader(), txnHeader); err != nil {
+			return nil, errors.NewProtobufDecodingError(err)
+		}
+		if txnHeader.GetBatcherPublicKey() != signerPublicKey {
+			return nil, errors.NewBatcherMismatchError(
+				txn.GetHeaderSignature(), signerPublicKey, txnHeader.GetBatcherPublicKey())
+		}
 		transactionIds[i] = txn.GetHeaderSignature()
 	}
 
 	header := &transaction_pb2.BatchHeader{
-		SignerPublicKey: signer.GetPublicKey().AsHex(),
+		SignerPublicKey: signerPublicKey,
 		TransactionIds:  transactionIds,
 	}
 
@@ -88,32 +98,43 @@ func (b *BatchBuilder) buildHeader(signer *signing.Signer) ([]byte, error) {
 	return headerBytes, nil
 }
 
-// Build returns a built batch as a byte slice or an error
-// if fields were missing or proto failed to marshal
-func (b *BatchBuilder) Build(signer *signing.Signer) ([]byte, error) {
+// BuildBatch returns a built and signed batch or an error
+// if fields were missing, a transaction names a different batcher
+// than the signer, or proto failed to marshal
+func (b *BatchBuilder) BuildBatch(signer signing.ISigner) (*transaction_pb2.Batch, error) {
 	header, err := b.buildHeader(signer)
 	if err != nil {
 		return nil, err
 	}
 
-	headerSignature := hex.EncodeToString(signer.Sign(header))
-	batch := &transaction_pb2.Batch{
+	signature, err := signer.Sign(header)
+	if err != nil {
+		return nil, err
+	}
+
+	headerSignature := hex.EncodeToString(signature)
+	return &transaction_pb2.Batch{
 		Header:          header,
 		HeaderSignature: headerSignature,
 		Transactions:    b.transactions,
 		Trace:           b.trace,
-	}
+	}, nil
+}
 
-	batchList := &transaction_pb2.BatchList{
-		Batches: []*transaction_pb2.Batch{
-			batch,
-		},
+// Build returns a batch list containing the built batch as a byte slice
+// or an error if the batch could not be built or proto failed to marshal
+func (b *BatchBuilder) Build(signer signing.ISigner) ([]byte, error) {
+	batch, err := b.BuildBatch(signer)
+	if err != nil {
+		return nil, err
 	}
 
-	batchListBytes, err := proto.Marshal(batchList)
+	batchListBuilder, err := NewBatchListBuilder(
+		WithBatches([]*transaction_pb2.Batch{batch}),
+	)
 	if err != nil {
-		return nil, errors.NewProtobufEncodingError(err)
+		return nil, err
 	}
 
-	return batchListBytes, nil
+	return batchListBuilder.Build()
 }
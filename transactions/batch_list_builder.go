@@ -0,0 +1,88 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package transactions
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+)
+
+// IBatchListBuilder defines the interface for building BatchLists of Batches
+type IBatchListBuilder interface {
+	GetBatches() []*transaction_pb2.Batch
+	AddBatches(batches ...*transaction_pb2.Batch)
+	BuildBatchList() (*transaction_pb2.BatchList, error)
+	Build() ([]byte, error)
+}
+
+// NewBatchListBuilder returns a new instance of IBatchListBuilder interface
+func NewBatchListBuilder(opts ...BatchListOption) (IBatchListBuilder, error) {
+	b := &BatchListBuilder{}
+	for _, opt := range opts {
+		err := opt(b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// BatchListBuilder implements the builder pattern for lists of batches
+// and implements the IBatchListBuilder interface
+type BatchListBuilder struct {
+	batches []*transaction_pb2.Batch
+}
+
+// GetBatches returns the slice of batches for the batch list
+func (b *BatchListBuilder) GetBatches() []*transaction_pb2.Batch {
+	return b.batches
+}
+
+// AddBatches appends built batches to the batch list, preserving order
+func (b *BatchListBuilder) AddBatches(batches ...*transaction_pb2.Batch) {
+	b.batches = append(b.batches, batches...)
+}
+
+func (b *BatchListBuilder) setBatches(batches []*transaction_pb2.Batch) { b.batches = batches }
+
+// BuildBatchList returns the batch list or an error if no batches were added
+func (b *BatchListBuilder) BuildBatchList() (*transaction_pb2.BatchList, error) {
+	if len(b.batches) == 0 {
+		return nil, errors.NewMissingFieldError("Batches")
+	}
+
+	return &transaction_pb2.BatchList{
+		Batches: b.batches,
+	}, nil
+}
+
+// Build returns the batch list as a byte slice or an error
+// if no batches were added or proto failed to marshal
+func (b *BatchListBuilder) Build() ([]byte, error) {
+	batchList, err := b.BuildBatchList()
+	if err != nil {
+		return nil, err
+	}
+
+	batchListBytes, err := proto.Marshal(batchList)
+	if err != nil {
+		return nil, errors.NewProtobufEncodingError(err)
+	}
+
+	return batchListBytes, nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package transactions
+
+import (
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+)
+
+// BatchListOption provide the functional options for building a BatchListBuilder
+type BatchListOption func(*BatchListBuilder) error
+
+// WithBatches sets the BatchList Option Batches
+func WithBatches(batches []*transaction_pb2.Batch) BatchListOption {
+	return func(b *BatchListBuilder) error {
+		b.setBatches(batches)
+		return nil
+	}
+}
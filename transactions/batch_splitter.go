@@ -0,0 +1,239 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package transactions
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+// Names of the limits reported in batching errors
+const (
+	maxBatchTransactions = "max batch transactions"
+	maxBatchBytes        = "max batch bytes"
+	maxBatchListBatches  = "max batch list batches"
+	maxBatchListBytes    = "max batch list bytes"
+)
+
+// signatureHexLength is the length of a hex encoded header signature
+// produced by the signing package's contexts
+const signatureHexLength = 128
+
+// IBatchSplitter defines the interface for splitting a stream of
+// transactions into batches and batch lists that respect size limits
+type IBatchSplitter interface {
+	Add(txns ...*transaction_pb2.Transaction)
+	AddAtomic(txns ...*transaction_pb2.Transaction)
+	BuildBatches(signer signing.ISigner) ([]*transaction_pb2.Batch, error)
+	BuildBatchLists(signer signing.ISigner) ([]*transaction_pb2.BatchList, error)
+	Build(signer signing.ISigner) ([][]byte, error)
+}
+
+// NewBatchSplitter returns a new instance of IBatchSplitter interface.
+// Limits that are not set are not enforced.
+func NewBatchSplitter(opts ...BatchSplitterOption) (IBatchSplitter, error) {
+	b := &BatchSplitter{}
+	for _, opt := range opts {
+		err := opt(b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// BatchSplitter packs transactions, in the order they were added, into as
+// few batches as the limits allow, then packs the batches into batch
+// lists. Transactions added together with AddAtomic are always placed in
+// the same batch. It implements the IBatchSplitter interface.
+type BatchSplitter struct {
+	groups               [][]*transaction_pb2.Transaction
+	maxBatchTransactions int
+	maxBatchBytes        int
+	maxBatchListBatches  int
+	maxBatchListBytes    int
+}
+
+func (b *BatchSplitter) setMaxBatchTransactions(max int) { b.maxBatchTransactions = max }
+func (b *BatchSplitter) setMaxBatchBytes(max int)        { b.maxBatchBytes = max }
+func (b *BatchSplitter) setMaxBatchListBatches(max int)  { b.maxBatchListBatches = max }
+func (b *BatchSplitter) setMaxBatchListBytes(max int)    { b.maxBatchListBytes = max }
+
+// Add adds transactions that may be placed in any batch
+func (b *BatchSplitter) Add(txns ...*transaction_pb2.Transaction) {
+	for _, txn := range txns {
+		b.groups = append(b.groups, []*transaction_pb2.Transaction{txn})
+	}
+}
+
+// AddAtomic adds transactions that must all be placed in the same batch,
+// so that they are committed or rejected together
+func (b *BatchSplitter) AddAtomic(txns ...*transaction_pb2.Transaction) {
+	if len(txns) > 0 {
+		b.groups = append(b.groups, txns)
+	}
+}
+
+// BuildBatches returns the added transactions as built and signed batches,
+// or an error if no transactions were added, a transaction or atomic group
+// exceeds the batch limits on its own, or a batch could not be built
+func (b *BatchSplitter) BuildBatches(signer signing.ISigner) ([]*transaction_pb2.Batch, error) {
+	if len(b.groups) == 0 {
+		return nil, errors.NewMissingFieldError("Transactions")
+	}
+
+	// A header always holds the signer's key, and a batch its header and signature
+	headerBase := fieldSize(len(signer.GetPublicKey().AsHex()))
+	batchSize := func(headerSize, txnsSize int) int {
+		return fieldSize(headerSize) + fieldSize(signatureHexLength) + txnsSize
+	}
+
+	var batches []*transaction_pb2.Batch
+	var current []*transaction_pb2.Transaction
+	headerSize, txnsSize := headerBase, 0
+
+	flush := func() error {
+		if len(current) == 0 {
+			return nil
+		}
+		batch, err := b.buildBatch(signer, current)
+		if err != nil {
+			return err
+		}
+		batches = append(batches, batch)
+		current = nil
+		headerSize, txnsSize = headerBase, 0
+		return nil
+	}
+
+	for _, group := range b.groups {
+		groupHeaderSize, groupTxnsSize := 0, 0
+		for _, txn := range group {
+			groupHeaderSize += fieldSize(len(txn.GetHeaderSignature()))
+			groupTxnsSize += fieldSize(proto.Size(txn))
+		}
+
+		if b.maxBatchTransactions > 0 && len(group) > b.maxBatchTransactions {
+			return nil, errors.NewBatchLimitExceededError(group[0].GetHeaderSignature(),
+				maxBatchTransactions, b.maxBatchTransactions, len(group))
+		}
+		if size := batchSize(headerBase+groupHeaderSize, groupTxnsSize); b.maxBatchBytes > 0 && size > b.maxBatchBytes {
+			return nil, errors.NewBatchLimitExceededError(group[0].GetHeaderSignature(),
+				maxBatchBytes, b.maxBatchBytes, size)
+		}
+
+		fitsCount := b.maxBatchTransactions == 0 || len(current)+len(group) <= b.maxBatchTransactions
+		fitsBytes := b.maxBatchBytes == 0 ||
+			batchSize(headerSize+groupHeaderSize, txnsSize+groupTxnsSize) <= b.maxBatchBytes
+		if !fitsCount || !fitsBytes {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+
+		current = append(current, group...)
+		headerSize += groupHeaderSize
+		txnsSize += groupTxnsSize
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return batches, nil
+}
+
+func (b *BatchSplitter) buildBatch(signer signing.ISigner, txns []*transaction_pb2.Transaction) (*transaction_pb2.Batch, error) {
+	batchBuilder, err := NewBatchBuilder(WithTransactions(txns))
+	if err != nil {
+		return nil, err
+	}
+	batch, err := batchBuilder.BuildBatch(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	// Signers outside this package may produce longer signatures than
+	// the size estimate allows for
+	if size := proto.Size(batch); b.maxBatchBytes > 0 && size > b.maxBatchBytes {
+		return nil, errors.NewBatchLimitExceededError(txns[0].GetHeaderSignature(),
+			maxBatchBytes, b.maxBatchBytes, size)
+	}
+	return batch, nil
+}
+
+// BuildBatchLists returns the built batches packed into batch lists, or an
+// error if the batches could not be built or a batch exceeds the batch
+// list limits on its own
+func (b *BatchSplitter) BuildBatchLists(signer signing.ISigner) ([]*transaction_pb2.BatchList, error) {
+	batches, err := b.BuildBatches(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	var batchLists []*transaction_pb2.BatchList
+	var current []*transaction_pb2.Batch
+	listSize := 0
+
+	for _, batch := range batches {
+		size := fieldSize(proto.Size(batch))
+		if b.maxBatchListBytes > 0 && size > b.maxBatchListBytes {
+			return nil, errors.NewBatchLimitExceededError(batch.GetTransactions()[0].GetHeaderSignature(),
+				maxBatchListBytes, b.maxBatchListBytes, size)
+		}
+
+		fitsCount := b.maxBatchListBatches == 0 || len(current) < b.maxBatchListBatches
+		fitsBytes := b.maxBatchListBytes == 0 || listSize+size <= b.maxBatchListBytes
+		if len(current) > 0 && (!fitsCount || !fitsBytes) {
+			batchLists = append(batchLists, &transaction_pb2.BatchList{Batches: current})
+			current, listSize = nil, 0
+		}
+
+		current = append(current, batch)
+		listSize += size
+	}
+	batchLists = append(batchLists, &transaction_pb2.BatchList{Batches: current})
+
+	return batchLists, nil
+}
+
+// Build returns each batch list as a byte slice, or an error if the batch
+// lists could not be built or proto failed to marshal
+func (b *BatchSplitter) Build(signer signing.ISigner) ([][]byte, error) {
+	batchLists, err := b.BuildBatchLists(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	batchListsBytes := make([][]byte, len(batchLists))
+	for i, batchList := range batchLists {
+		batchListBytes, err := proto.Marshal(batchList)
+		if err != nil {
+			return nil, errors.NewProtobufEncodingError(err)
+		}
+		batchListsBytes[i] = batchListBytes
+	}
+
+	return batchListsBytes, nil
+}
+
+// fieldSize returns the encoded size of a length-delimited protobuf field
+// with a single byte tag
+func fieldSize(length int) int {
+	return 1 + proto.SizeVarint(uint64(length)) + length
+}
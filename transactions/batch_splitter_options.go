@@ -0,0 +1,71 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package transactions
+
+import (
+	"github.com/hyperledger/transact-sdk-go/errors"
+)
+
+// BatchSplitterOption provides the functional options for creating a BatchSplitter
+type BatchSplitterOption func(*BatchSplitter) error
+
+// WithMaxBatchTransactions sets the BatchSplitter Option for the most
+// transactions a batch may hold
+func WithMaxBatchTransactions(max int) BatchSplitterOption {
+	return func(b *BatchSplitter) error {
+		if max < 1 {
+			return errors.NewInvalidBatchLimitError(maxBatchTransactions, max)
+		}
+		b.setMaxBatchTransactions(max)
+		return nil
+	}
+}
+
+// WithMaxBatchBytes sets the BatchSplitter Option for the largest
+// serialized size of a batch
+func WithMaxBatchBytes(max int) BatchSplitterOption {
+	return func(b *BatchSplitter) error {
+		if max < 1 {
+			return errors.NewInvalidBatchLimitError(maxBatchBytes, max)
+		}
+		b.setMaxBatchBytes(max)
+		return nil
+	}
+}
+
+// WithMaxBatchListBatches sets the BatchSplitter Option for the most
+// batches a batch list may hold
+func WithMaxBatchListBatches(max int) BatchSplitterOption {
+	return func(b *BatchSplitter) error {
+		if max < 1 {
+			return errors.NewInvalidBatchLimitError(maxBatchListBatches, max)
+		}
+		b.setMaxBatchListBatches(max)
+		return nil
+	}
+}
+
+// WithMaxBatchListBytes sets the BatchSplitter Option for the largest
+// serialized size of a batch list
+func WithMaxBatchListBytes(max int) BatchSplitterOption {
+	return func(b *BatchSplitter) error {
+		if max < 1 {
+			return errors.NewInvalidBatchLimitError(maxBatchListBytes, max)
+		}
+		b.setMaxBatchListBytes(max)
+		return nil
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package transactions
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+// IBuildable defines the interface for builders that produce a signed
+// Transaction, such as TransactionBuilder and SabreTransactionBuilder
+type IBuildable interface {
+	Build(signer signing.ISigner) (*transaction_pb2.Transaction, error)
+}
+
+// BuildMany builds and signs a Transaction for each of the given builders
+// using at most workers goroutines. A workers value below 1 uses one
+// goroutine per CPU. The transactions are returned in the same order as
+// the builders. The signer must be safe for concurrent use, which the
+// signing package's Signer is. If any builder fails, the remaining
+// builders are skipped and a TransactionBuildError is returned for the
+// failed builder with the lowest index.
+func BuildMany(builders []IBuildable, signer signing.ISigner, workers int) ([]*transaction_pb2.Transaction, error) {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(builders) {
+		workers = len(builders)
+	}
+
+	txns := make([]*transaction_pb2.Transaction, len(builders))
+	errs := make([]error, len(builders))
+
+	var failed int32
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if atomic.LoadInt32(&failed) != 0 {
+					continue
+				}
+				txn, err := builders[i].Build(signer)
+				if err != nil {
+					errs[i] = err
+					atomic.StoreInt32(&failed, 1)
+					continue
+				}
+				txns[i] = txn
+			}
+		}()
+	}
+
+	for i := range builders {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, errors.NewTransactionBuildError(i, err)
+		}
+	}
+
+	return txns, nil
+}
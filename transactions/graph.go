@@ -0,0 +1,219 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package transactions
+
+import (
+	"container/heap"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+// IDependentBuildable defines the interface for builders that can add
+// dependencies, known only once other transactions are signed, at build
+// time. TransactionBuilder and SabreTransactionBuilder implement it; an
+// ITransactionBuilder can be checked for it with a type assertion.
+type IDependentBuildable interface {
+	BuildWithDependencies(signer signing.ISigner, dependencies []string) (*transaction_pb2.Transaction, error)
+}
+
+// IGraph defines the interface for building transactions that depend
+// on each other
+type IGraph interface {
+	AddNode(id string, builder IDependentBuildable) error
+	AddDependency(id string, dependsOn string) error
+	Build(signer signing.ISigner) ([]*transaction_pb2.Transaction, error)
+	BuildBatches(signer signing.ISigner, opts ...BatchSplitterOption) ([]*transaction_pb2.Batch, error)
+}
+
+// NewGraph returns a new, empty instance of IGraph interface
+func NewGraph() IGraph {
+	return &Graph{index: make(map[string]int)}
+}
+
+// Graph holds transaction builders keyed by caller chosen IDs, and the
+// dependencies between them. It implements the IGraph interface.
+type Graph struct {
+	nodes []*graphNode
+	index map[string]int
+}
+
+type graphNode struct {
+	id        string
+	builder   IDependentBuildable
+	dependsOn []int
+}
+
+// AddNode adds a builder to the graph under the given ID, or returns a
+// DuplicateNodeError if the ID is already in use
+func (g *Graph) AddNode(id string, builder IDependentBuildable) error {
+	if _, ok := g.index[id]; ok {
+		return errors.NewDuplicateNodeError(id)
+	}
+	g.index[id] = len(g.nodes)
+	g.nodes = append(g.nodes, &graphNode{id: id, builder: builder})
+	return nil
+}
+
+// AddDependency records that the node id depends on the node dependsOn,
+// or returns an UnknownNodeError if either node has not been added
+func (g *Graph) AddDependency(id string, dependsOn string) error {
+	node, ok := g.index[id]
+	if !ok {
+		return errors.NewUnknownNodeError(id)
+	}
+	dependency, ok := g.index[dependsOn]
+	if !ok {
+		return errors.NewUnknownNodeError(dependsOn)
+	}
+	for _, existing := range g.nodes[node].dependsOn {
+		if existing == dependency {
+			return nil
+		}
+	}
+	g.nodes[node].dependsOn = append(g.nodes[node].dependsOn, dependency)
+	return nil
+}
+
+// Build signs every node's transaction after the transactions it depends
+// on, setting their IDs as its dependencies. Nodes that do not depend on
+// each other are built in the order they were added. Returns the
+// transactions in build order, or a DependencyCycleError if the
+// dependencies form a cycle.
+func (g *Graph) Build(signer signing.ISigner) ([]*transaction_pb2.Transaction, error) {
+	order, err := g.sort()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(g.nodes))
+	txns := make([]*transaction_pb2.Transaction, 0, len(g.nodes))
+	for _, i := range order {
+		node := g.nodes[i]
+
+		dependencies := make([]string, len(node.dependsOn))
+		for j, dependency := range node.dependsOn {
+			dependencies[j] = ids[dependency]
+		}
+
+		txn, err := node.builder.BuildWithDependencies(signer, dependencies)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = txn.GetHeaderSignature()
+		txns = append(txns, txn)
+	}
+
+	return txns, nil
+}
+
+// BuildBatches builds the graph's transactions and packs them, in build
+// order, into batches using a BatchSplitter created with the given
+// options. A transaction is never placed in an earlier batch than the
+// transactions it depends on.
+func (g *Graph) BuildBatches(signer signing.ISigner, opts ...BatchSplitterOption) ([]*transaction_pb2.Batch, error) {
+	splitter, err := NewBatchSplitter(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	txns, err := g.Build(signer)
+	if err != nil {
+		return nil, err
+	}
+	splitter.Add(txns...)
+
+	return splitter.BuildBatches(signer)
+}
+
+// sort returns the node indexes in topological order, breaking ties by
+// the order the nodes were added
+func (g *Graph) sort() ([]int, error) {
+	pending := make([]int, len(g.nodes))
+	dependents := make([][]int, len(g.nodes))
+	for i, node := range g.nodes {
+		pending[i] = len(node.dependsOn)
+		for _, dependency := range node.dependsOn {
+			dependents[dependency] = append(dependents[dependency], i)
+		}
+	}
+
+	ready := &indexHeap{}
+	for i := range g.nodes {
+		if pending[i] == 0 {
+			heap.Push(ready, i)
+		}
+	}
+
+	order := make([]int, 0, len(g.nodes))
+	for ready.Len() > 0 {
+		i := heap.Pop(ready).(int)
+		order = append(order, i)
+		for _, dependent := range dependents[i] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				heap.Push(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) < len(g.nodes) {
+		var cycle []string
+		for i, node := range g.nodes {
+			if pending[i] > 0 {
+				cycle = append(cycle, node.id)
+			}
+		}
+		return nil, errors.NewDependencyCycleError(cycle)
+	}
+
+	return order, nil
+}
+
+// indexHeap is a min-heap of node indexes
+type indexHeap []int
+
+func (h indexHeap) Len() int            { return len(h) }
+func (h indexHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h indexHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *indexHeap) Push(x interface{}) { *h = append(*h, x.(int)) }
+func (h *indexHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// MergeDependencies returns the given transaction IDs followed by the
+// additional IDs that are not already present, preserving order
+func MergeDependencies(dependencies []string, additional []string) []string {
+	if len(additional) == 0 {
+		return dependencies
+	}
+
+	merged := make([]string, 0, len(dependencies)+len(additional))
+	seen := make(map[string]bool, len(dependencies)+len(additional))
+	for _, list := range [][]string{dependencies, additional} {
+		for _, id := range list {
+			if !seen[id] {
+				seen[id] = true
+				merged = append(merged, id)
+			}
+		}
+	}
+	return merged
+}
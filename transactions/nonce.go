@@ -0,0 +1,108 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package transactions
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// randomNonceBytes is the number of random bytes in a random nonce
+const randomNonceBytes = 16
+
+// INonceGenerator provides the interface for generating the nonces
+// that make otherwise identical transactions unique
+type INonceGenerator interface {
+	NextNonce() (string, error)
+}
+
+// INonceSource is implemented by transaction builders, such as
+// TransactionBuilder, that draw a new nonce for each transaction they build
+type INonceSource interface {
+	GetNonceGenerator() INonceGenerator
+	NextNonce() (string, error)
+}
+
+// NewRandomNonceGenerator returns an INonceGenerator producing hex encoded
+// nonces from a cryptographically secure random source. This is the default
+// generator for transactions built without an explicit nonce.
+func NewRandomNonceGenerator() INonceGenerator {
+	return &RandomNonceGenerator{}
+}
+
+// RandomNonceGenerator implements INonceGenerator with crypto/rand
+type RandomNonceGenerator struct{}
+
+// NextNonce returns a new random hex encoded nonce
+func (r *RandomNonceGenerator) NextNonce() (string, error) {
+	b := make([]byte, randomNonceBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// NewTimeNonceGenerator returns an INonceGenerator producing the current
+// time in nanoseconds since the Unix epoch. Nonces from the same generator
+// are strictly increasing, even when the clock does not advance between calls.
+func NewTimeNonceGenerator() INonceGenerator {
+	return &TimeNonceGenerator{}
+}
+
+// TimeNonceGenerator implements INonceGenerator with a monotonic timestamp
+type TimeNonceGenerator struct {
+	mu   sync.Mutex
+	last int64
+}
+
+// NextNonce returns the next timestamp nonce as a decimal string
+func (g *TimeNonceGenerator) NextNonce() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now().UnixNano()
+	if now <= g.last {
+		now = g.last + 1
+	}
+	g.last = now
+	return strconv.FormatInt(now, 10), nil
+}
+
+// NewCounterNonceGenerator returns an INonceGenerator producing a
+// deterministic sequence of decimal nonces beginning at start. It is
+// intended for tests that need reproducible transactions.
+func NewCounterNonceGenerator(start uint64) INonceGenerator {
+	return &CounterNonceGenerator{next: start}
+}
+
+// CounterNonceGenerator implements INonceGenerator with an incrementing counter
+type CounterNonceGenerator struct {
+	mu   sync.Mutex
+	next uint64
+}
+
+// NextNonce returns the next counter value as a decimal string
+func (c *CounterNonceGenerator) NextNonce() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	nonce := strconv.FormatUint(c.next, 10)
+	c.next++
+	return nonce, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package transactions
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/sabre_pb2"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+)
+
+// sabreFamilyName mirrors sabre.SabreFamilyName, which cannot be imported
+// here without an import cycle
+const sabreFamilyName = "sabre"
+
+// ParsedTransaction is a Transaction alongside its decoded header
+type ParsedTransaction struct {
+	// ID is the transaction's header signature
+	ID string
+	// Header is the decoded TransactionHeader
+	Header *transaction_pb2.TransactionHeader
+	// Transaction is the raw transaction message
+	Transaction *transaction_pb2.Transaction
+	// SabrePayload is the decoded payload of a sabre transaction, or nil
+	// for any other family or a sabre payload that cannot be decoded
+	SabrePayload *sabre_pb2.SabrePayload
+}
+
+// ParsedBatch is a Batch alongside its decoded header and transactions
+type ParsedBatch struct {
+	// ID is the batch's header signature
+	ID string
+	// Header is the decoded BatchHeader
+	Header *transaction_pb2.BatchHeader
+	// Batch is the raw batch message
+	Batch *transaction_pb2.Batch
+	// Transactions are the batch's parsed transactions, in order
+	Transactions []*ParsedTransaction
+}
+
+// ParseTransaction decodes the header of a transaction and, for the
+// sabre family, its payload. An undecodable sabre payload is left nil.
+// Returns a ParsedTransaction or a ProtobufDecodingError for the header
+func ParseTransaction(txn *transaction_pb2.Transaction) (*ParsedTransaction, error) {
+	header := &transaction_pb2.TransactionHeader{}
+	if err := proto.Unmarshal(txn.GetHeader(), header); err != nil {
+		return nil, errors.NewProtobufDecodingError(err)
+	}
+
+	parsed := &ParsedTransaction{
+		ID:          txn.GetHeaderSignature(),
+		Header:      header,
+		Transaction: txn,
+	}
+
+	if header.GetFamilyName() == sabreFamilyName {
+		payload := &sabre_pb2.SabrePayload{}
+		if err := proto.Unmarshal(txn.GetPayload(), payload); err == nil {
+			parsed.SabrePayload = payload
+		}
+	}
+
+	return parsed, nil
+}
+
+// ParseBatch decodes the header of a batch and parses each of its
+// transactions.
+// Returns a ParsedBatch or a ProtobufDecodingError
+func ParseBatch(batch *transaction_pb2.Batch) (*ParsedBatch, error) {
+	header := &transaction_pb2.BatchHeader{}
+	if err := proto.Unmarshal(batch.GetHeader(), header); err != nil {
+		return nil, errors.NewProtobufDecodingError(err)
+	}
+
+	txns := make([]*ParsedTransaction, len(batch.GetTransactions()))
+	for i, txn := range batch.GetTransactions() {
+		parsed, err := ParseTransaction(txn)
+		if err != nil {
+			return nil, err
+		}
+		txns[i] = parsed
+	}
+
+	return &ParsedBatch{
+		ID:           batch.GetHeaderSignature(),
+		Header:       header,
+		Batch:        batch,
+		Transactions: txns,
+	}, nil
+}
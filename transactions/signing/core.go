@@ -17,6 +17,10 @@
 
 package signing
 
+import (
+	"sync"
+)
+
 // -- Keys --
 
 // A private key instance. The underlying content is dependent on
@@ -59,40 +63,62 @@ type Context interface {
 	GetPublicKey(private_key PrivateKey) PublicKey
 
 	// Sign uses the given private key to calculate a signature for
-	// the given data.
-	Sign(message []byte, private_key PrivateKey) []byte
+	// the given data. Returns an error if the private key is invalid
+	// or signing fails.
+	Sign(message []byte, private_key PrivateKey) ([]byte, error)
 
 	// Verify uses the given public key to verify that the given
 	// signature was created from the given data using the associated
-	// private key.
-	Verify(signature []byte, message []byte, public_key PublicKey) bool
+	// private key. Returns an error if the signature or public key
+	// cannot be decoded.
+	Verify(signature []byte, message []byte, public_key PublicKey) (bool, error)
 }
 
-// Returns a Context instance by name.
+// Returns a Context instance by name. Panics if there is no such
+// algorithm; use NewContext when the name comes from user input.
 func CreateContext(algorithmName string) Context {
-	if algorithmName == "secp256k1" {
-		return NewSecp256k1Context()
+	context, err := NewContext(algorithmName)
+	if err != nil {
+		panic(err.Error())
 	}
 
-	panic("No such algorithm")
+	return context
 }
 
 // -- Signer --
 
-// A convenient wrapper of Context and PrivateKey.
+// Anything that can sign messages on behalf of a public key. Implementations
+// may hold the private key in memory, like Signer, or delegate to an
+// external process such as an HSM-backed daemon or remote signing service.
+type ISigner interface {
+	// Returns the public key whose private key produces the signatures.
+	GetPublicKey() PublicKey
+
+	// Signs the given message.
+	Sign(message []byte) ([]byte, error)
+}
+
+// A convenient wrapper of Context and PrivateKey, and the default ISigner.
 type Signer struct {
 	context     Context
 	private_key PrivateKey
+
+	// The public key is derived on first use and reused for every header
+	publicKeyOnce sync.Once
+	public_key    PublicKey
 }
 
 // Signs the given message.
-func (self *Signer) Sign(message []byte) []byte {
+func (self *Signer) Sign(message []byte) ([]byte, error) {
 	return self.context.Sign(message, self.private_key)
 }
 
 // Returns the public key for this Signer instance.
 func (self *Signer) GetPublicKey() PublicKey {
-	return self.context.GetPublicKey(self.private_key)
+	self.publicKeyOnce.Do(func() {
+		self.public_key = self.context.GetPublicKey(self.private_key)
+	})
+	return self.public_key
 }
 
 // -- CryptoFactory --
@@ -114,5 +140,5 @@ func (self *CryptoFactory) GetContext() Context {
 
 // Creates a new Signer for the given private key.
 func (self *CryptoFactory) NewSigner(private_key PrivateKey) *Signer {
-	return &Signer{self.context, private_key}
+	return &Signer{context: self.context, private_key: private_key}
 }
@@ -0,0 +1,172 @@
+/**
+ * Copyright 2020 Tyson Foods, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ------------------------------------------------------------------------------
+ */
+
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+)
+
+// -- Private Key --
+
+type Ed25519PrivateKey struct {
+	private_key []byte
+}
+
+// Creates a PrivateKey instance from the 32 byte private key seed.
+func NewEd25519PrivateKey(private_key []byte) PrivateKey {
+	return &Ed25519PrivateKey{private_key}
+}
+
+// Returns the string "ed25519".
+func (self *Ed25519PrivateKey) GetAlgorithmName() string {
+	return "ed25519"
+}
+
+// Returns the private key seed as a hex-encoded string.
+func (self *Ed25519PrivateKey) AsHex() string {
+	return hex.EncodeToString(self.private_key)
+}
+
+// Returns the bytes of the private key seed.
+func (self *Ed25519PrivateKey) AsBytes() []byte {
+	return self.private_key
+}
+
+// -- Public Key --
+
+type Ed25519PublicKey struct {
+	public_key []byte
+}
+
+// Creates a PublicKey instance from public key bytes.
+func NewEd25519PublicKey(public_key []byte) PublicKey {
+	return &Ed25519PublicKey{public_key}
+}
+
+// Returns the string "ed25519".
+func (self *Ed25519PublicKey) GetAlgorithmName() string {
+	return "ed25519"
+}
+
+// Returns the public key as a hex-encoded string.
+func (self *Ed25519PublicKey) AsHex() string {
+	return hex.EncodeToString(self.public_key)
+}
+
+// Returns the bytes of the public key.
+func (self *Ed25519PublicKey) AsBytes() []byte {
+	return self.public_key
+}
+
+// -- Context --
+
+type Ed25519Context struct{}
+
+// Returns a new ed25519 context.
+func NewEd25519Context() Context {
+	return &Ed25519Context{}
+}
+
+// Returns the string "ed25519".
+func (self *Ed25519Context) GetAlgorithmName() string {
+	return "ed25519"
+}
+
+// Returns an ed25519 private key for the given seed, or an error if it is
+// the wrong length.
+func (self *Ed25519Context) NewPrivateKey(private_key []byte) (PrivateKey, error) {
+	if err := validateEd25519PrivateKey(private_key); err != nil {
+		return nil, err
+	}
+	return NewEd25519PrivateKey(private_key), nil
+}
+
+// Returns an ed25519 public key for the given bytes, or an error if they
+// are the wrong length.
+func (self *Ed25519Context) NewPublicKey(public_key []byte) (PublicKey, error) {
+	if err := validateEd25519PublicKey(public_key); err != nil {
+		return nil, err
+	}
+	return NewEd25519PublicKey(public_key), nil
+}
+
+// Generates a new random ed25519 private key.
+func (self *Ed25519Context) NewRandomPrivateKey() PrivateKey {
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+
+	return &Ed25519PrivateKey{priv.Seed()}
+}
+
+// Produces a public key for the given private key. An invalid private
+// key produces an empty public key.
+func (self *Ed25519Context) GetPublicKey(private_key PrivateKey) PublicKey {
+	if validateEd25519PrivateKey(private_key.AsBytes()) != nil {
+		return NewEd25519PublicKey(nil)
+	}
+	priv := ed25519.NewKeyFromSeed(private_key.AsBytes())
+
+	return NewEd25519PublicKey(priv.Public().(ed25519.PublicKey))
+}
+
+// Sign uses the given private key to calculate a signature for the
+// given data. Ed25519 hashes the data itself, so the message is signed
+// as is. Returns the 64 byte signature, or an error if the private key
+// is not a 32 byte seed.
+func (self *Ed25519Context) Sign(message []byte, private_key PrivateKey) ([]byte, error) {
+	if err := validateEd25519PrivateKey(private_key.AsBytes()); err != nil {
+		return nil, err
+	}
+	priv := ed25519.NewKeyFromSeed(private_key.AsBytes())
+
+	return ed25519.Sign(priv, message), nil
+}
+
+// Verify uses the given public key to verify that the given signature
+// was created from the given data using the associated private key.
+// Returns an error if the signature is not 64 bytes or the public key
+// is not 32 bytes.
+func (self *Ed25519Context) Verify(signature []byte, message []byte, public_key PublicKey) (bool, error) {
+	if len(signature) != ed25519.SignatureSize {
+		return false, errors.NewMalformedSignatureError(len(signature), ed25519.SignatureSize)
+	}
+	if err := validateEd25519PublicKey(public_key.AsBytes()); err != nil {
+		return false, err
+	}
+
+	return ed25519.Verify(public_key.AsBytes(), message, signature), nil
+}
+
+// ---
+
+func validateEd25519PrivateKey(b []byte) error {
+	if len(b) != ed25519.SeedSize {
+		return errors.NewInvalidPrivateKeyError("ed25519 private keys must be 32 byte seeds")
+	}
+	return nil
+}
+
+func validateEd25519PublicKey(b []byte) error {
+	if len(b) != ed25519.PublicKeySize {
+		return errors.NewInvalidPublicKeyError(hex.EncodeToString(b))
+	}
+	return nil
+}
@@ -0,0 +1,426 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+// Package keystore stores named signing keys in a directory, encrypting
+// each key with AES-256-GCM under a key derived from a passphrase by scrypt.
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+const (
+	// keyFileExtension is the extension of key files in the keystore directory
+	keyFileExtension = ".key"
+	// keyFileVersion is the current key file format version
+	keyFileVersion = 1
+	// keyFileMode restricts key files to their owner
+	keyFileMode = 0600
+	// archiveSuffix ends the names of keys archived by Rotate
+	archiveSuffix = ".old"
+
+	// DefaultScryptN is the default scrypt CPU/memory cost parameter
+	DefaultScryptN = 1 << 15
+	// DefaultScryptR is the default scrypt block size parameter
+	DefaultScryptR = 8
+	// DefaultScryptP is the default scrypt parallelization parameter
+	DefaultScryptP = 1
+
+	// The largest scrypt parameters a key file may use, so that a corrupt
+	// or crafted file cannot exhaust memory or CPU. Memory use is 128*N*r
+	// bytes, which is bounded separately.
+	maxScryptN      = 1 << 20
+	maxScryptR      = 32
+	maxScryptP      = 16
+	maxScryptMemory = 1 << 30
+
+	kdfScrypt       = "scrypt"
+	cipherAES256GCM = "aes-256-gcm"
+
+	saltLength = 32
+	keyLength  = 32
+)
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]*$`)
+
+// keyFile is the JSON document stored for each key
+type keyFile struct {
+	Version    int          `json:"version"`
+	Algorithm  string       `json:"algorithm"`
+	PublicKey  string       `json:"public_key"`
+	KDF        string       `json:"kdf"`
+	KDFParams  scryptParams `json:"kdfparams"`
+	Cipher     string       `json:"cipher"`
+	Nonce      string       `json:"nonce"`
+	Ciphertext string       `json:"ciphertext"`
+}
+
+type scryptParams struct {
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+	Salt string `json:"salt"`
+}
+
+// NewKeystore returns a Keystore backed by dir, creating the directory
+// if it does not exist
+func NewKeystore(dir string, opts ...KeystoreOption) (*Keystore, error) {
+	k := &Keystore{
+		dir:     dir,
+		factory: signing.NewCryptoFactory(signing.NewSecp256k1Context()),
+		scryptN: DefaultScryptN,
+		scryptR: DefaultScryptR,
+		scryptP: DefaultScryptP,
+	}
+	for _, opt := range opts {
+		if err := opt(k); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Keystore stores named private keys as encrypted files in a directory
+type Keystore struct {
+	dir     string
+	factory *signing.CryptoFactory
+	scryptN int
+	scryptR int
+	scryptP int
+}
+
+func (k *Keystore) setFactory(factory *signing.CryptoFactory) { k.factory = factory }
+func (k *Keystore) setScryptParameters(n, r, p int) {
+	k.scryptN = n
+	k.scryptR = r
+	k.scryptP = p
+}
+
+// GetDirectory returns the directory backing the keystore
+func (k *Keystore) GetDirectory() string { return k.dir }
+
+// List returns the names of the stored keys in sorted order
+func (k *Keystore) List() ([]string, error) {
+	entries, err := ioutil.ReadDir(k.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, keyFileExtension) {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(name, keyFileExtension))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Save encrypts the private key with the passphrase and stores it under
+// name. Returns a KeyExistsError if the name is already in use, or an
+// UnknownAlgorithmError if the key is not for the keystore's algorithm.
+func (k *Keystore) Save(name string, key signing.PrivateKey, passphrase string) error {
+	path, err := k.path(name)
+	if err != nil {
+		return err
+	}
+	// Skip the expensive encryption when the name is plainly taken; write
+	// still refuses to replace a key saved concurrently
+	if _, err := os.Stat(path); err == nil {
+		return errors.NewKeyExistsError(name)
+	}
+	return k.write(name, key, passphrase, false)
+}
+
+// Load decrypts and returns the private key stored under name. Returns a
+// KeyNotFoundError if there is no such key, or a DecryptionError if the
+// passphrase is wrong.
+func (k *Keystore) Load(name string, passphrase string) (signing.PrivateKey, error) {
+	file, err := k.read(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if file.Algorithm != k.factory.GetContext().GetAlgorithmName() {
+		return nil, errors.NewUnknownAlgorithmError(file.Algorithm)
+	}
+	if file.KDF != kdfScrypt {
+		return nil, errors.NewUnknownAlgorithmError(file.KDF)
+	}
+	if file.Cipher != cipherAES256GCM {
+		return nil, errors.NewUnknownAlgorithmError(file.Cipher)
+	}
+	if err := validateScryptParameters(file.KDFParams.N, file.KDFParams.R, file.KDFParams.P); err != nil {
+		return nil, err
+	}
+
+	salt, err := hex.DecodeString(file.KDFParams.Salt)
+	if err != nil {
+		return nil, errors.NewDecryptionError(name)
+	}
+	nonce, err := hex.DecodeString(file.Nonce)
+	if err != nil {
+		return nil, errors.NewDecryptionError(name)
+	}
+	ciphertext, err := hex.DecodeString(file.Ciphertext)
+	if err != nil {
+		return nil, errors.NewDecryptionError(name)
+	}
+
+	aead, err := newAEAD(passphrase, salt, file.KDFParams.N, file.KDFParams.R, file.KDFParams.P)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.NewDecryptionError(name)
+	}
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData(name, file))
+	if err != nil {
+		return nil, errors.NewDecryptionError(name)
+	}
+
+	return signing.NewPrivateKey(file.Algorithm, plaintext)
+}
+
+// LoadSigner loads the private key stored under name and returns a Signer
+// for it created by the keystore's CryptoFactory
+func (k *Keystore) LoadSigner(name string, passphrase string) (*signing.Signer, error) {
+	key, err := k.Load(name, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return k.factory.NewSigner(key), nil
+}
+
+// GetPublicKey returns the hex-encoded public key stored under name
+// without requiring the passphrase
+func (k *Keystore) GetPublicKey(name string) (string, error) {
+	file, err := k.read(name)
+	if err != nil {
+		return "", err
+	}
+	return file.PublicKey, nil
+}
+
+// Delete removes the key stored under name. Returns a KeyNotFoundError if
+// there is no such key.
+func (k *Keystore) Delete(name string) error {
+	path, err := k.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return errors.NewKeyNotFoundError(name)
+		}
+		return err
+	}
+	return nil
+}
+
+// Rotate replaces the key stored under name with a newly generated key,
+// encrypted with the same passphrase, and returns the new key. The
+// passphrase must decrypt the current key. The current key is archived
+// first under the name "<name>.<timestamp>.old", where timestamp is the
+// rotation time in Unix nanoseconds, and can be loaded from there with
+// the same passphrase.
+func (k *Keystore) Rotate(name string, passphrase string) (signing.PrivateKey, error) {
+	old, err := k.Load(name, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := fmt.Sprintf("%s.%d%s", name, time.Now().UnixNano(), archiveSuffix)
+	if err := k.write(archive, old, passphrase, false); err != nil {
+		return nil, err
+	}
+
+	key := k.factory.GetContext().NewRandomPrivateKey()
+	if err := k.write(name, key, passphrase, true); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// ChangePassphrase re-encrypts the key stored under name with a new
+// passphrase. The old passphrase must decrypt the current key.
+func (k *Keystore) ChangePassphrase(name string, oldPassphrase string, newPassphrase string) error {
+	key, err := k.Load(name, oldPassphrase)
+	if err != nil {
+		return err
+	}
+	return k.write(name, key, newPassphrase, true)
+}
+
+func (k *Keystore) path(name string) (string, error) {
+	if !validName.MatchString(name) {
+		return "", errors.NewInvalidKeyNameError(name)
+	}
+	return filepath.Join(k.dir, name+keyFileExtension), nil
+}
+
+func (k *Keystore) read(name string) (*keyFile, error) {
+	path, err := k.path(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.NewKeyNotFoundError(name)
+		}
+		return nil, err
+	}
+
+	file := &keyFile{}
+	if err := json.Unmarshal(data, file); err != nil || file.Version != keyFileVersion {
+		return nil, errors.NewDecryptionError(name)
+	}
+	return file, nil
+}
+
+// write encrypts the key and atomically stores it under name. Unless
+// replace is set, it returns a KeyExistsError if the name is in use.
+func (k *Keystore) write(name string, key signing.PrivateKey, passphrase string, replace bool) error {
+	path, err := k.path(name)
+	if err != nil {
+		return err
+	}
+	if key.GetAlgorithmName() != k.factory.GetContext().GetAlgorithmName() {
+		return errors.NewUnknownAlgorithmError(key.GetAlgorithmName())
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	aead, err := newAEAD(passphrase, salt, k.scryptN, k.scryptR, k.scryptP)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	file := &keyFile{
+		Version:   keyFileVersion,
+		Algorithm: key.GetAlgorithmName(),
+		PublicKey: k.factory.GetContext().GetPublicKey(key).AsHex(),
+		KDF:       kdfScrypt,
+		KDFParams: scryptParams{
+			N:    k.scryptN,
+			R:    k.scryptR,
+			P:    k.scryptP,
+			Salt: hex.EncodeToString(salt),
+		},
+		Cipher: cipherAES256GCM,
+		Nonce:  hex.EncodeToString(nonce),
+	}
+	file.Ciphertext = hex.EncodeToString(
+		aead.Seal(nil, nonce, key.AsBytes(), additionalData(name, file)))
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(k.dir, "."+name+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(keyFileMode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if replace {
+		return os.Rename(tmp.Name(), path)
+	}
+	// Linking fails if the name exists, unlike renaming
+	if err := os.Link(tmp.Name(), path); err != nil {
+		if os.IsExist(err) {
+			return errors.NewKeyExistsError(name)
+		}
+		return err
+	}
+	return nil
+}
+
+// validateScryptParameters returns an InvalidScryptParametersError unless
+// N is a power of two greater than one and the parameters are within the
+// keystore's limits
+func validateScryptParameters(n, r, p int) error {
+	if n <= 1 || n > maxScryptN || n&(n-1) != 0 ||
+		r < 1 || r > maxScryptR ||
+		p < 1 || p > maxScryptP ||
+		int64(128)*int64(n)*int64(r) > maxScryptMemory {
+		return errors.NewInvalidScryptParametersError(n, r, p)
+	}
+	return nil
+}
+
+// newAEAD derives an AES-256-GCM cipher from the passphrase
+func newAEAD(passphrase string, salt []byte, n, r, p int) (cipher.AEAD, error) {
+	derived, err := scrypt.Key([]byte(passphrase), salt, n, r, p, keyLength)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(derived)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// additionalData binds the ciphertext to the key's name and public metadata,
+// so a key file cannot be renamed or have its public key swapped unnoticed
+func additionalData(name string, file *keyFile) []byte {
+	return []byte(strings.Join([]string{name, file.Algorithm, file.PublicKey}, "\n"))
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package keystore
+
+import (
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+// KeystoreOption provides the functional options for creating a Keystore
+type KeystoreOption func(*Keystore) error
+
+// WithCryptoFactory sets the factory used to generate keys and Signers.
+// Defaults to a secp256k1 factory.
+func WithCryptoFactory(factory *signing.CryptoFactory) KeystoreOption {
+	return func(k *Keystore) error {
+		k.setFactory(factory)
+		return nil
+	}
+}
+
+// WithScryptParameters sets the scrypt cost parameters used when saving
+// keys. Lower costs speed up tests at the expense of brute force resistance;
+// keys saved with other parameters remain loadable. N must be a power of
+// two, and parameters using more than 1 GiB of memory are rejected.
+func WithScryptParameters(n, r, p int) KeystoreOption {
+	return func(k *Keystore) error {
+		if err := validateScryptParameters(n, r, p); err != nil {
+			return err
+		}
+		k.setScryptParameters(n, r, p)
+		return nil
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing/keystore"
+)
+
+func newKeystore(t *testing.T) (*keystore.Keystore, func()) {
+	dir, err := ioutil.TempDir("", "keystore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks, err := keystore.NewKeystore(filepath.Join(dir, "keys"),
+		keystore.WithScryptParameters(1<<10, 8, 1))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return ks, func() { os.RemoveAll(dir) }
+}
+
+func TestKeystore(t *testing.T) {
+	ks, cleanup := newKeystore(t)
+	defer cleanup()
+
+	context := signing.NewSecp256k1Context()
+	alice := context.NewRandomPrivateKey()
+	bob := context.NewRandomPrivateKey()
+
+	if err := ks.Save("alice", alice, "alice passphrase"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Save("bob", bob, "bob passphrase"); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := ks.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"alice", "bob"}) {
+		t.Error("Expected [alice bob], got", names)
+	}
+
+	info, err := os.Stat(filepath.Join(ks.GetDirectory(), "alice.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected key file mode 0600, got %o", info.Mode().Perm())
+	}
+
+	loaded, err := ks.Load("alice", "alice passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.AsHex() != alice.AsHex() {
+		t.Error("Loaded key does not match saved key")
+	}
+
+	pub, err := ks.GetPublicKey("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub != context.GetPublicKey(alice).AsHex() {
+		t.Error("Stored public key does not match saved key")
+	}
+
+	signer, err := ks.LoadSigner("bob", "bob passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer.GetPublicKey().AsHex() != context.GetPublicKey(bob).AsHex() {
+		t.Error("Signer has the wrong public key")
+	}
+
+	if err := ks.Delete("bob"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Load("bob", "bob passphrase"); !isKeyNotFound(err) {
+		t.Error("Expected KeyNotFoundError after delete, got", err)
+	}
+}
+
+func TestKeystoreErrors(t *testing.T) {
+	ks, cleanup := newKeystore(t)
+	defer cleanup()
+
+	key := signing.NewSecp256k1Context().NewRandomPrivateKey()
+	if err := ks.Save("alice", key, "passphrase"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := ks.Save("alice", key, "passphrase").(errors.KeyExistsError); !ok {
+		t.Error("Expected KeyExistsError saving a duplicate name")
+	}
+	if _, err := ks.Load("alice", "wrong"); !isDecryption(err) {
+		t.Error("Expected DecryptionError for wrong passphrase, got", err)
+	}
+	if _, err := ks.Load("carol", "passphrase"); !isKeyNotFound(err) {
+		t.Error("Expected KeyNotFoundError, got", err)
+	}
+	if _, ok := ks.Delete("carol").(errors.KeyNotFoundError); !ok {
+		t.Error("Expected KeyNotFoundError deleting a missing key")
+	}
+	for _, name := range []string{"", "../alice", ".hidden", "a/b"} {
+		if _, ok := ks.Save(name, key, "passphrase").(errors.InvalidKeyNameError); !ok {
+			t.Errorf("Expected InvalidKeyNameError for %q", name)
+		}
+	}
+
+	// A key file copied to another name must not decrypt
+	data, err := ioutil.ReadFile(filepath.Join(ks.GetDirectory(), "alice.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(ks.GetDirectory(), "mallory.key"), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Load("mallory", "passphrase"); !isDecryption(err) {
+		t.Error("Expected DecryptionError for renamed key file, got", err)
+	}
+}
+
+func TestKeystoreRejectsKeyFiles(t *testing.T) {
+	ks, cleanup := newKeystore(t)
+	defer cleanup()
+
+	key := signing.NewSecp256k1Context().NewRandomPrivateKey()
+	if err := ks.Save("alice", key, "passphrase"); err != nil {
+		t.Fatal(err)
+	}
+	original, err := ioutil.ReadFile(filepath.Join(ks.GetDirectory(), "alice.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vectors := []struct {
+		name   string
+		modify func(file map[string]interface{}, params map[string]interface{})
+		check  func(err error) bool
+	}{
+		{"huge N", func(f, p map[string]interface{}) { p["n"] = 1 << 30 }, isInvalidScrypt},
+		{"N not a power of two", func(f, p map[string]interface{}) { p["n"] = 1000 }, isInvalidScrypt},
+		{"huge r", func(f, p map[string]interface{}) { p["r"] = 1 << 20 }, isInvalidScrypt},
+		{"huge p", func(f, p map[string]interface{}) { p["p"] = 1 << 20 }, isInvalidScrypt},
+		{"N and r over the memory limit", func(f, p map[string]interface{}) {
+			p["n"] = 1 << 20
+			p["r"] = 16
+		}, isInvalidScrypt},
+		{"zero r", func(f, p map[string]interface{}) { p["r"] = 0 }, isInvalidScrypt},
+		{"unsupported KDF", func(f, p map[string]interface{}) { f["kdf"] = "pbkdf2" }, isUnknownAlgorithm},
+		{"unsupported cipher", func(f, p map[string]interface{}) { f["cipher"] = "aes-128-ctr" }, isUnknownAlgorithm},
+	}
+
+	for _, v := range vectors {
+		file := map[string]interface{}{}
+		if err := json.Unmarshal(original, &file); err != nil {
+			t.Fatal(err)
+		}
+		v.modify(file, file["kdfparams"].(map[string]interface{}))
+
+		data, err := json.Marshal(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(ks.GetDirectory(), "alice.key"), data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ks.Load("alice", "passphrase"); !v.check(err) {
+			t.Errorf("%s: unexpected error %v", v.name, err)
+		}
+	}
+}
+
+func TestKeystoreScryptParameterOption(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, params := range [][3]int{{1000, 8, 1}, {1 << 30, 8, 1}, {1 << 10, 0, 1}, {1 << 10, 8, 0}} {
+		_, err := keystore.NewKeystore(dir, keystore.WithScryptParameters(params[0], params[1], params[2]))
+		if !isInvalidScrypt(err) {
+			t.Errorf("%v: expected InvalidScryptParametersError, got %v", params, err)
+		}
+	}
+}
+
+func TestKeystoreSaveWrongAlgorithm(t *testing.T) {
+	ks, cleanup := newKeystore(t)
+	defer cleanup()
+
+	key := signing.NewEd25519Context().NewRandomPrivateKey()
+	if err := ks.Save("alice", key, "passphrase"); !isUnknownAlgorithm(err) {
+		t.Error("Expected UnknownAlgorithmError saving an ed25519 key, got", err)
+	}
+	if names, _ := ks.List(); len(names) != 0 {
+		t.Error("Expected no saved keys, got", names)
+	}
+}
+
+func TestKeystoreConcurrentSave(t *testing.T) {
+	ks, cleanup := newKeystore(t)
+	defer cleanup()
+
+	context := signing.NewSecp256k1Context()
+	results := make([]error, 8)
+	keys := make([]signing.PrivateKey, len(results))
+
+	var wg sync.WaitGroup
+	for i := range results {
+		keys[i] = context.NewRandomPrivateKey()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = ks.Save("alice", keys[i], "passphrase")
+		}(i)
+	}
+	wg.Wait()
+
+	saved := -1
+	for i, err := range results {
+		if err == nil {
+			if saved >= 0 {
+				t.Fatal("Expected only one concurrent Save to succeed")
+			}
+			saved = i
+		} else if _, ok := err.(errors.KeyExistsError); !ok {
+			t.Error("Expected KeyExistsError, got", err)
+		}
+	}
+	if saved < 0 {
+		t.Fatal("Expected one concurrent Save to succeed")
+	}
+
+	loaded, err := ks.Load("alice", "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.AsHex() != keys[saved].AsHex() {
+		t.Error("Stored key is not the one whose Save succeeded")
+	}
+}
+
+func TestKeystoreRotation(t *testing.T) {
+	ks, cleanup := newKeystore(t)
+	defer cleanup()
+
+	original := signing.NewSecp256k1Context().NewRandomPrivateKey()
+	if err := ks.Save("service", original, "first"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ks.Rotate("service", "wrong"); !isDecryption(err) {
+		t.Error("Expected DecryptionError rotating with wrong passphrase, got", err)
+	}
+
+	rotated, err := ks.Rotate("service", "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated.AsHex() == original.AsHex() {
+		t.Error("Rotation did not generate a new key")
+	}
+
+	if err := ks.ChangePassphrase("service", "first", "second"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Load("service", "first"); !isDecryption(err) {
+		t.Error("Old passphrase still decrypts the key")
+	}
+	loaded, err := ks.Load("service", "second")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.AsHex() != rotated.AsHex() {
+		t.Error("Loaded key does not match rotated key")
+	}
+}
+
+func TestKeystoreRotationArchivesOldKey(t *testing.T) {
+	ks, cleanup := newKeystore(t)
+	defer cleanup()
+
+	original := signing.NewSecp256k1Context().NewRandomPrivateKey()
+	if err := ks.Save("service", original, "passphrase"); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := ks.Rotate("service", "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Rotate("service", "passphrase"); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := ks.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	archived := map[string]bool{}
+	for _, name := range names {
+		if name == "service" {
+			continue
+		}
+		if !strings.HasPrefix(name, "service.") || !strings.HasSuffix(name, ".old") {
+			t.Fatal("Unexpected key name", name)
+		}
+		key, err := ks.Load(name, "passphrase")
+		if err != nil {
+			t.Fatal(err)
+		}
+		archived[key.AsHex()] = true
+	}
+
+	if len(archived) != 2 || !archived[original.AsHex()] || !archived[first.AsHex()] {
+		t.Error("Expected the original and first rotated keys to be archived, got", names)
+	}
+}
+
+func isKeyNotFound(err error) bool {
+	_, ok := err.(errors.KeyNotFoundError)
+	return ok
+}
+
+func isInvalidScrypt(err error) bool {
+	_, ok := err.(errors.InvalidScryptParametersError)
+	return ok
+}
+
+func isUnknownAlgorithm(err error) bool {
+	_, ok := err.(errors.UnknownAlgorithmError)
+	return ok
+}
+
+func isDecryption(err error) bool {
+	_, ok := err.(errors.DecryptionError)
+	return ok
+}
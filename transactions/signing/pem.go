@@ -0,0 +1,85 @@
+/**
+ * Copyright 2017 Intel Corporation
+ * Copyright 2020 Tyson Foods, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ------------------------------------------------------------------------------
+ */
+
+package signing
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"strings"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+)
+
+// secp256k1OID is the named curve identifier for secp256k1 (1.3.132.0.10)
+var secp256k1OID = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+
+// ecPrivateKey is the SEC1 ECPrivateKey structure (RFC 5915)
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// Encode a private key and its public key as an "EC PRIVATE KEY" PEM
+// string. If a password is given, the PEM is encrypted with AES-128-CBC
+// using the legacy Proc-Type and DEK-Info headers understood by OpenSSL.
+func encodePemKey(priv []byte, pub []byte, oid asn1.ObjectIdentifier, password string) (string, error) {
+	der, err := asn1.Marshal(ecPrivateKey{
+		Version:       1,
+		PrivateKey:    priv,
+		NamedCurveOID: oid,
+		PublicKey:     asn1.BitString{Bytes: pub, BitLength: 8 * len(pub)},
+	})
+	if err != nil {
+		return "", errors.NewPemEncodingError(err)
+	}
+
+	block := &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}
+	if password != "" {
+		block, err = x509.EncryptPEMBlock(rand.Reader, block.Type, der,
+			[]byte(password), x509.PEMCipherAES128)
+		if err != nil {
+			return "", errors.NewPemEncodingError(err)
+		}
+	}
+
+	return string(pem.EncodeToMemory(block)), nil
+}
+
+// normalizePem strips the indentation that commonly creeps into PEMs
+// embedded in source code and configuration, which encoding/pem rejects.
+// It also separates any Proc-Type and DEK-Info headers from the encoded
+// key with the blank line OpenSSL requires.
+func normalizePem(pemstr string) string {
+	var lines []string
+	inHeaders := false
+	for _, line := range strings.Split(strings.TrimSpace(pemstr), "\n") {
+		line = strings.TrimSpace(line)
+		isHeader := strings.Contains(line, ":")
+		if inHeaders && !isHeader && line != "" {
+			lines = append(lines, "")
+		}
+		inHeaders = isHeader
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
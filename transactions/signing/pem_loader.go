@@ -1,3 +1,6 @@
+//go:build !openssl || !cgo
+// +build !openssl !cgo
+
 /**
  * Copyright 2017 Intel Corporation
  * Copyright 2020 Tyson Foods, Inc.
@@ -18,38 +21,80 @@
 
 package signing
 
-// #cgo LDFLAGS: -lcrypto
-// #include "../../c/c11_support.h"
-// #include "../../c/c11_support.c"
-// #include "../../c/loader.c"
-import "C"
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+
+	ellcurv "github.com/btcsuite/btcd/btcec"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+)
 
-import "fmt"
+// The pure Go PEM loader is the default. Building with the openssl tag
+// selects the original cgo loader, which links against libcrypto.
 
+// Extract the private and public keys from an "EC PRIVATE KEY" PEM string,
+// using the supplied password to decrypt legacy encrypted (Proc-Type and
+// DEK-Info) PEMs. Keys are returned hex encoded.
 func loadPemKey(pemstr string, pemstrLen int, password string) (priv_key string, pub_key string, err error) {
-	cPemstr := C.CString(pemstr)
-	cPemstrLen := C.size_t(pemstrLen)
-	cPassword := C.CString(password)
-	cOutPrivKey := C.CString("-----------------------------------------------------------------")
-	cOutPubKey := C.CString("-----------------------------------------------------------------------------------------------------------------------------------")
-	errnum := C.load_pem_key(cPemstr, cPemstrLen, cPassword, cOutPrivKey, cOutPubKey)
-	if errnum < 0 {
-		var errstr string
-		switch errnum {
-		case -1:
-			errstr = "Failed to decrypt or decode private key"
-		case -2:
-			errstr = "Failed to create new big number context"
-		case -3:
-			errstr = "Failed to load group"
-		case -4:
-			errstr = "Failed to load private key"
-		case -5:
-			errstr = "Failed to load public key point"
-		case -6:
-			errstr = "Failed to construct public key from point"
+	// Skip any leading blocks, such as the EC PARAMETERS block that
+	// openssl ecparam -genkey writes before the key
+	var block *pem.Block
+	rest := []byte(normalizePem(pemstr[:pemstrLen]))
+	skipped := ""
+	for {
+		block, rest = pem.Decode(rest)
+		if block == nil && skipped == "" {
+			return "", "", errors.NewPemDecodingError("no PEM block found")
+		}
+		if block == nil {
+			return "", "", errors.NewPemDecodingError("unsupported PEM type " + skipped)
+		}
+		if block.Type == "EC PRIVATE KEY" {
+			break
+		}
+		if skipped == "" {
+			skipped = block.Type
+		}
+	}
+
+	der := block.Bytes
+	if x509.IsEncryptedPEMBlock(block) {
+		if password == "" {
+			return "", "", errors.NewPemDecodingError("PEM is encrypted but no password was given")
+		}
+		der, err = x509.DecryptPEMBlock(block, []byte(password))
+		if err != nil {
+			return "", "", errors.NewPemDecodingError(err.Error())
 		}
-		return "", "", fmt.Errorf(errstr)
 	}
-	return C.GoString(cOutPrivKey), C.GoString(cOutPubKey), nil
+
+	var key ecPrivateKey
+	if _, err := asn1.Unmarshal(der, &key); err != nil {
+		return "", "", errors.NewPemDecodingError(err.Error())
+	}
+	if key.Version != 1 {
+		return "", "", errors.NewPemDecodingError("unsupported EC private key version")
+	}
+	if len(key.NamedCurveOID) != 0 && !key.NamedCurveOID.Equal(secp256k1OID) {
+		return "", "", errors.NewPemDecodingError("unsupported curve " + key.NamedCurveOID.String())
+	}
+	if len(key.PrivateKey) > 32 {
+		return "", "", errors.NewPemDecodingError("private key is longer than 32 bytes")
+	}
+
+	priv := pad(key.PrivateKey, 32)
+	if err := validateSecp256k1PrivateKey(priv); err != nil {
+		return "", "", err
+	}
+
+	pub := key.PublicKey.RightAlign()
+	if len(pub) == 0 {
+		_, public_key := ellcurv.PrivKeyFromBytes(cachedCurve, priv)
+		pub = public_key.SerializeUncompressed()
+	}
+
+	return hex.EncodeToString(priv), hex.EncodeToString(pub), nil
 }
@@ -0,0 +1,141 @@
+/**
+ * Copyright 2020 Tyson Foods, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ------------------------------------------------------------------------------
+ */
+
+package signing
+
+import (
+	"encoding/hex"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+)
+
+// -- Registry --
+
+// Creates a new Context for a registered algorithm.
+type ContextFactory func() Context
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[string]ContextFactory{
+		"secp256k1": func() Context { return NewSecp256k1Context() },
+		"secp256r1": NewSecp256r1Context,
+		"ed25519":   NewEd25519Context,
+	}
+)
+
+// Registers a Context factory under the given algorithm name, making it
+// available to NewContext and CreateContext. Registering a name again
+// replaces the previous factory, so a built-in algorithm can be backed by
+// a different implementation.
+func RegisterContext(algorithmName string, factory ContextFactory) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	registry[algorithmName] = factory
+}
+
+// Returns the names of all registered algorithms in sorted order.
+func RegisteredAlgorithms() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns a Context instance by name, or an UnknownAlgorithmError.
+func NewContext(algorithmName string) (Context, error) {
+	registryLock.RLock()
+	factory, ok := registry[algorithmName]
+	registryLock.RUnlock()
+
+	if !ok || factory == nil {
+		return nil, errors.NewUnknownAlgorithmError(algorithmName)
+	}
+
+	return factory(), nil
+}
+
+// -- Keys --
+
+// KeyParser is implemented by Contexts that construct and validate their
+// own keys from bytes. The built-in contexts implement it.
+type KeyParser interface {
+	// Returns a private key for the given bytes, or an error if they are
+	// not a valid key.
+	NewPrivateKey(private_key []byte) (PrivateKey, error)
+
+	// Returns a public key for the given bytes, or an error if they are
+	// not a valid key.
+	NewPublicKey(public_key []byte) (PublicKey, error)
+}
+
+// Creates a PrivateKey for the named algorithm from private key bytes,
+// using the algorithm's registered Context when it is a KeyParser. Keys
+// for other registered algorithms are passed through for their Context
+// to interpret.
+func NewPrivateKey(algorithmName string, private_key []byte) (PrivateKey, error) {
+	context, err := NewContext(algorithmName)
+	if err != nil {
+		return nil, err
+	}
+	if parser, ok := context.(KeyParser); ok {
+		return parser.NewPrivateKey(private_key)
+	}
+	return &rawPrivateKey{algorithmName, private_key}, nil
+}
+
+// Creates a PublicKey for the named algorithm from public key bytes,
+// using the algorithm's registered Context when it is a KeyParser. Keys
+// for other registered algorithms are passed through for their Context
+// to interpret.
+func NewPublicKey(algorithmName string, public_key []byte) (PublicKey, error) {
+	context, err := NewContext(algorithmName)
+	if err != nil {
+		return nil, err
+	}
+	if parser, ok := context.(KeyParser); ok {
+		return parser.NewPublicKey(public_key)
+	}
+	return &rawPublicKey{algorithmName, public_key}, nil
+}
+
+// rawPrivateKey holds the bytes of a private key for a registered algorithm
+type rawPrivateKey struct {
+	algorithm   string
+	private_key []byte
+}
+
+func (self *rawPrivateKey) GetAlgorithmName() string { return self.algorithm }
+func (self *rawPrivateKey) AsHex() string            { return hex.EncodeToString(self.private_key) }
+func (self *rawPrivateKey) AsBytes() []byte          { return self.private_key }
+
+// rawPublicKey holds the bytes of a public key for a registered algorithm
+type rawPublicKey struct {
+	algorithm  string
+	public_key []byte
+}
+
+func (self *rawPublicKey) GetAlgorithmName() string { return self.algorithm }
+func (self *rawPublicKey) AsHex() string            { return hex.EncodeToString(self.public_key) }
+func (self *rawPublicKey) AsBytes() []byte          { return self.public_key }
@@ -21,22 +21,56 @@ import (
 	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/hex"
+	"fmt"
 	"math/big"
+	"strings"
+	"sync"
 
 	ellcurv "github.com/btcsuite/btcd/btcec"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
 )
 
 var cachedCurve = ellcurv.S256()
 
+// compactHeaderCompressed is the header byte btcec uses for a compact
+// signature of a compressed key with recovery id 0
+const compactHeaderCompressed = 27 + 4
+
+// halfOrder is used to normalize signatures to low-S form
+var halfOrder = new(big.Int).Rsh(cachedCurve.N, 1)
+
 // -- Private Key --
 
 type Secp256k1PrivateKey struct {
 	private_key []byte
+
+	// The parsed key and its compressed public key are computed on first
+	// use and shared by every signature
+	parseOnce  sync.Once
+	parsed     *ellcurv.PrivateKey
+	parseErr   error
+	public_key []byte
 }
 
 // Creates a PrivateKey instance from private key bytes.
 func NewSecp256k1PrivateKey(private_key []byte) PrivateKey {
-	return &Secp256k1PrivateKey{private_key}
+	return &Secp256k1PrivateKey{private_key: private_key}
+}
+
+// Creates a PrivateKey instance from a hex-encoded private key, as found
+// in Sawtooth and Splinter .priv files. Returns an InvalidPrivateKeyError
+// if the key is not 32 bytes or is not a valid secp256k1 scalar.
+func NewSecp256k1PrivateKeyFromHex(private_key string) (PrivateKey, error) {
+	priv, err := hex.DecodeString(strings.TrimSpace(private_key))
+	if err != nil {
+		return nil, errors.NewInvalidPrivateKeyError("private key is not hex encoded")
+	}
+	if err := validateSecp256k1PrivateKey(priv); err != nil {
+		return nil, err
+	}
+
+	return &Secp256k1PrivateKey{private_key: priv}, nil
 }
 
 // PemToSecp256k1PrivateKey converts a PEM string to a private key.
@@ -46,7 +80,19 @@ func PemToSecp256k1PrivateKey(pem string, password string) (*Secp256k1PrivateKey
 		return nil, err
 	}
 
-	return &Secp256k1PrivateKey{priv}, nil
+	return &Secp256k1PrivateKey{private_key: priv}, nil
+}
+
+// Secp256k1PrivateKeyToPem converts a private key to an "EC PRIVATE KEY"
+// PEM string, encrypting it if a password is given.
+func Secp256k1PrivateKeyToPem(private_key PrivateKey, password string) (string, error) {
+	priv := private_key.AsBytes()
+	if err := validateSecp256k1PrivateKey(priv); err != nil {
+		return "", err
+	}
+
+	_, public_key := ellcurv.PrivKeyFromBytes(cachedCurve, priv)
+	return encodePemKey(priv, public_key.SerializeUncompressed(), secp256k1OID, password)
 }
 
 // Returns the string "secp256k1".
@@ -75,6 +121,22 @@ func NewSecp256k1PublicKey(public_key []byte) PublicKey {
 	return &Secp256k1PublicKey{public_key}
 }
 
+// Creates a PublicKey instance from a hex-encoded public key, as found in
+// Sawtooth and Splinter .pub files. Returns an InvalidPublicKeyError if the
+// key is not a compressed or uncompressed encoding of a point on the curve.
+func NewSecp256k1PublicKeyFromHex(public_key string) (PublicKey, error) {
+	public_key = strings.TrimSpace(public_key)
+	pub, err := hex.DecodeString(public_key)
+	if err != nil {
+		return nil, errors.NewInvalidPublicKeyError(public_key)
+	}
+	if _, err := parseSecp256k1PublicKey(pub); err != nil {
+		return nil, err
+	}
+
+	return &Secp256k1PublicKey{pub}, nil
+}
+
 // Returns the string "secp256k1".
 func (self *Secp256k1PublicKey) GetAlgorithmName() string {
 	return "secp256k1"
@@ -85,20 +147,26 @@ func (self *Secp256k1PublicKey) AsHex() string {
 	return hex.EncodeToString(self.public_key)
 }
 
-// Returns the bytes of the public key.
+// Returns a copy of the bytes of the public key, so that callers cannot
+// change a key cached by a Signer.
 func (self *Secp256k1PublicKey) AsBytes() []byte {
-	return self.public_key
+	return append([]byte(nil), self.public_key...)
 }
 
 // -- Context --
 
 type Secp256k1Context struct {
 	curve *ellcurv.KoblitzCurve
+	lowS  bool
 }
 
 // Returns a new secp256k1 context.
-func NewSecp256k1Context() Context {
-	return &Secp256k1Context{ellcurv.S256()}
+func NewSecp256k1Context(opts ...Secp256k1ContextOption) Context {
+	context := &Secp256k1Context{curve: ellcurv.S256()}
+	for _, opt := range opts {
+		opt(context)
+	}
+	return context
 }
 
 // Returns the string "secp256k1".
@@ -106,15 +174,41 @@ func (self *Secp256k1Context) GetAlgorithmName() string {
 	return "secp256k1"
 }
 
+// Returns a secp256k1 private key for the given bytes, or an error if
+// they are not a valid key.
+func (self *Secp256k1Context) NewPrivateKey(private_key []byte) (PrivateKey, error) {
+	if err := validateSecp256k1PrivateKey(private_key); err != nil {
+		return nil, err
+	}
+	return NewSecp256k1PrivateKey(private_key), nil
+}
+
+// Returns a secp256k1 public key for the given compressed or uncompressed
+// bytes, or an error if they are not a point on the curve.
+func (self *Secp256k1Context) NewPublicKey(public_key []byte) (PublicKey, error) {
+	if _, err := parseSecp256k1PublicKey(public_key); err != nil {
+		return nil, err
+	}
+	return NewSecp256k1PublicKey(public_key), nil
+}
+
 // Generates a new random secp256k1 private key.
 func (self *Secp256k1Context) NewRandomPrivateKey() PrivateKey {
 	priv, _ := ellcurv.NewPrivateKey(cachedCurve)
 
-	return &Secp256k1PrivateKey{priv.Serialize()}
+	return &Secp256k1PrivateKey{private_key: priv.Serialize()}
 }
 
-// Produces a public key for the given private key.
+// Produces a public key for the given private key. The public key of a
+// valid key created by this package is derived once, and each call
+// returns a copy of it.
 func (self *Secp256k1Context) GetPublicKey(private_key PrivateKey) PublicKey {
+	if key, ok := private_key.(*Secp256k1PrivateKey); ok {
+		if _, err := parseSecp256k1PrivateKey(key); err == nil {
+			return NewSecp256k1PublicKey(append([]byte(nil), key.public_key...))
+		}
+	}
+
 	_, public_key := ellcurv.PrivKeyFromBytes(
 		cachedCurve,
 		private_key.AsBytes())
@@ -124,39 +218,105 @@ func (self *Secp256k1Context) GetPublicKey(private_key PrivateKey) PublicKey {
 
 // Sign uses the given private key to calculate a signature for the
 // given data. A sha256 hash of the data is first calculated and this
-// is what is actually signed. Returns the signature as bytes using
-// the compact serialization (which is just (r, s)).
-func (self *Secp256k1Context) Sign(message []byte, private_key PrivateKey) []byte {
-	priv, _ := ellcurv.PrivKeyFromBytes(
-		self.curve,
-		private_key.AsBytes())
+// is what is actually signed. The nonce is derived from the key and
+// hash as described in RFC6979, so signing the same data with the same
+// key always gives the same signature, and S is normalized to the lower
+// half of the curve order. Returns the signature as bytes using the
+// compact serialization (which is just (r, s)), or an error if the
+// private key is not a valid secp256k1 scalar.
+func (self *Secp256k1Context) Sign(message []byte, private_key PrivateKey) ([]byte, error) {
+	priv, err := parseSecp256k1PrivateKey(private_key)
+	if err != nil {
+		return nil, err
+	}
 
 	hash := doSHA256(message)
 
 	sig, err := priv.Sign(hash)
 	if err != nil {
-		panic("Signing failed")
+		return nil, errors.NewSigningError(err)
+	}
+	if sig.S.Cmp(halfOrder) > 0 {
+		sig.S = new(big.Int).Sub(self.curve.N, sig.S)
 	}
 
-	return serializeCompact(sig)
+	return serializeCompact(sig), nil
 }
 
 // Verify uses the given public key to verify that the given signature
 // was created from the given data using the associated private key. A
 // sha256 hash of the data is calculated first and this is what is
-// actually used to verify the signature.
-func (self *Secp256k1Context) Verify(signature []byte, message []byte, public_key PublicKey) bool {
-	sig := deserializeCompact(signature)
+// actually used to verify the signature. When the context was created
+// WithLowSVerification, signatures with a high S value do not verify.
+// Returns an error if the signature is not 64 bytes or the public key
+// is not a valid point.
+func (self *Secp256k1Context) Verify(signature []byte, message []byte, public_key PublicKey) (bool, error) {
+	sig, err := deserializeCompact(signature)
+	if err != nil {
+		return false, err
+	}
+	if self.lowS && sig.S.Cmp(halfOrder) > 0 {
+		return false, nil
+	}
 	hash := doSHA256(message)
 
-	pub, err := ellcurv.ParsePubKey(
-		public_key.AsBytes(),
-		self.curve)
+	pub, err := parseSecp256k1PublicKey(public_key.AsBytes())
+	if err != nil {
+		return false, err
+	}
+
+	return sig.Verify(hash, pub), nil
+}
+
+// SignRecoverable calculates a signature for the given data in the same
+// way as Sign, then appends a recovery id. Returns 65 bytes, r || s || v,
+// where r || s is the compact signature returned by Sign and v is the
+// recovery id in the range 0 to 3.
+func (self *Secp256k1Context) SignRecoverable(message []byte, private_key PrivateKey) ([]byte, error) {
+	priv, err := parseSecp256k1PrivateKey(private_key)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := doSHA256(message)
+
+	// SignCompact returns <header byte><r><s>, where the header byte is
+	// 27 + recovery id, plus 4 for a compressed key
+	compact, err := ellcurv.SignCompact(self.curve, priv, hash, true)
+	if err != nil {
+		return nil, errors.NewSigningError(err)
+	}
+
+	b := make([]byte, 0, 65)
+	b = append(b, compact[1:]...)
+	return append(b, compact[0]-compactHeaderCompressed), nil
+}
+
+// RecoverPublicKey recovers the public key of the signer from a 65 byte
+// signature produced by SignRecoverable and the signed data. The 64 byte
+// signatures produced by Sign do not carry a recovery id, so the key
+// cannot be recovered from them. Returns the compressed public key, or an
+// error if the signature is malformed or names no key.
+func (self *Secp256k1Context) RecoverPublicKey(signature []byte, message []byte) (PublicKey, error) {
+	if len(signature) != 65 {
+		return nil, errors.NewMalformedSignatureError(len(signature), 65)
+	}
+	recoveryID := signature[64]
+	if recoveryID > 3 {
+		return nil, errors.NewPublicKeyRecoveryError(
+			fmt.Sprintf("recovery id %d is out of range", recoveryID))
+	}
+
+	compact := make([]byte, 0, 65)
+	compact = append(compact, compactHeaderCompressed+recoveryID)
+	compact = append(compact, signature[:64]...)
+
+	pub, _, err := ellcurv.RecoverCompact(self.curve, compact, doSHA256(message))
 	if err != nil {
-		panic(err.Error())
+		return nil, errors.NewPublicKeyRecoveryError(err.Error())
 	}
 
-	return sig.Verify(hash, pub)
+	return NewSecp256k1PublicKey(pub.SerializeCompressed()), nil
 }
 
 // -- SHA --
@@ -197,11 +357,59 @@ func serializeCompact(sig *ellcurv.Signature) []byte {
 	return b
 }
 
-func deserializeCompact(b []byte) *ellcurv.Signature {
+func deserializeCompact(b []byte) (*ellcurv.Signature, error) {
+	if len(b) != 64 {
+		return nil, errors.NewMalformedSignatureError(len(b), 64)
+	}
 	return &ellcurv.Signature{
 		R: new(big.Int).SetBytes(b[:32]),
 		S: new(big.Int).SetBytes(b[32:]),
+	}, nil
+}
+
+// parseSecp256k1PublicKey parses a compressed or uncompressed public key,
+// rejecting encodings that do not name a point on the curve
+func parseSecp256k1PublicKey(b []byte) (*ellcurv.PublicKey, error) {
+	pub, err := ellcurv.ParsePubKey(b, cachedCurve)
+	if err != nil || pub.X.Cmp(cachedCurve.P) >= 0 || !cachedCurve.IsOnCurve(pub.X, pub.Y) {
+		return nil, errors.NewInvalidPublicKeyError(hex.EncodeToString(b))
+	}
+	return pub, nil
+}
+
+// parseSecp256k1PrivateKey returns the btcec key for a PrivateKey. Keys
+// created by this package are parsed once and reused; other PrivateKey
+// implementations are parsed on every call.
+func parseSecp256k1PrivateKey(private_key PrivateKey) (*ellcurv.PrivateKey, error) {
+	if key, ok := private_key.(*Secp256k1PrivateKey); ok {
+		key.parseOnce.Do(func() {
+			key.parsed, key.parseErr = parseSecp256k1PrivateKeyBytes(key.private_key)
+			if key.parseErr == nil {
+				key.public_key = key.parsed.PubKey().SerializeCompressed()
+			}
+		})
+		return key.parsed, key.parseErr
+	}
+	return parseSecp256k1PrivateKeyBytes(private_key.AsBytes())
+}
+
+func parseSecp256k1PrivateKeyBytes(b []byte) (*ellcurv.PrivateKey, error) {
+	if err := validateSecp256k1PrivateKey(b); err != nil {
+		return nil, err
+	}
+	priv, _ := ellcurv.PrivKeyFromBytes(cachedCurve, b)
+	return priv, nil
+}
+
+func validateSecp256k1PrivateKey(b []byte) error {
+	if len(b) != 32 {
+		return errors.NewInvalidPrivateKeyError("secp256k1 private keys must be 32 bytes")
+	}
+	d := new(big.Int).SetBytes(b)
+	if d.Sign() == 0 || d.Cmp(cachedCurve.N) >= 0 {
+		return errors.NewInvalidPrivateKeyError("secp256k1 private key is out of range")
 	}
+	return nil
 }
 
 func pad(buf []byte, size int) []byte {
@@ -0,0 +1,32 @@
+/**
+ * Copyright 2020 Tyson Foods, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ------------------------------------------------------------------------------
+ */
+
+package signing
+
+// Secp256k1ContextOption provides the functional option for creating a new
+// secp256k1 Context
+type Secp256k1ContextOption func(c *Secp256k1Context)
+
+// WithLowSVerification provides the Secp256k1ContextOption for rejecting
+// signatures whose S value is in the upper half of the curve order. Such
+// signatures are valid ECDSA but are refused by validators that require
+// canonical low-S signatures. Signatures produced by Sign are always low-S.
+func WithLowSVerification() Secp256k1ContextOption {
+	return func(c *Secp256k1Context) {
+		c.lowS = true
+	}
+}
@@ -0,0 +1,240 @@
+/**
+ * Copyright 2020 Tyson Foods, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ------------------------------------------------------------------------------
+ */
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+)
+
+// -- Private Key --
+
+type Secp256r1PrivateKey struct {
+	private_key []byte
+}
+
+// Creates a PrivateKey instance from private key bytes.
+func NewSecp256r1PrivateKey(private_key []byte) PrivateKey {
+	return &Secp256r1PrivateKey{private_key}
+}
+
+// Returns the string "secp256r1".
+func (self *Secp256r1PrivateKey) GetAlgorithmName() string {
+	return "secp256r1"
+}
+
+// Returns the private key as a hex-encoded string.
+func (self *Secp256r1PrivateKey) AsHex() string {
+	return hex.EncodeToString(self.private_key)
+}
+
+// Returns the bytes of the private key.
+func (self *Secp256r1PrivateKey) AsBytes() []byte {
+	return self.private_key
+}
+
+// -- Public Key --
+
+type Secp256r1PublicKey struct {
+	public_key []byte
+}
+
+// Creates a PublicKey instance from public key bytes.
+func NewSecp256r1PublicKey(public_key []byte) PublicKey {
+	return &Secp256r1PublicKey{public_key}
+}
+
+// Returns the string "secp256r1".
+func (self *Secp256r1PublicKey) GetAlgorithmName() string {
+	return "secp256r1"
+}
+
+// Returns the public key as a hex-encoded string.
+func (self *Secp256r1PublicKey) AsHex() string {
+	return hex.EncodeToString(self.public_key)
+}
+
+// Returns the bytes of the public key.
+func (self *Secp256r1PublicKey) AsBytes() []byte {
+	return self.public_key
+}
+
+// -- Context --
+
+type Secp256r1Context struct {
+	curve elliptic.Curve
+}
+
+// Returns a new secp256r1 (NIST P-256) context.
+func NewSecp256r1Context() Context {
+	return &Secp256r1Context{elliptic.P256()}
+}
+
+// Returns the string "secp256r1".
+func (self *Secp256r1Context) GetAlgorithmName() string {
+	return "secp256r1"
+}
+
+// Returns a secp256r1 private key for the given bytes, or an error if
+// they are not a valid key.
+func (self *Secp256r1Context) NewPrivateKey(private_key []byte) (PrivateKey, error) {
+	if _, err := parseSecp256r1PrivateKey(private_key); err != nil {
+		return nil, err
+	}
+	return NewSecp256r1PrivateKey(private_key), nil
+}
+
+// Returns a secp256r1 public key for the given compressed or uncompressed
+// bytes, or an error if they are not a point on the curve.
+func (self *Secp256r1Context) NewPublicKey(public_key []byte) (PublicKey, error) {
+	if _, err := parseSecp256r1PublicKey(public_key); err != nil {
+		return nil, err
+	}
+	return NewSecp256r1PublicKey(public_key), nil
+}
+
+// Generates a new random secp256r1 private key.
+func (self *Secp256r1Context) NewRandomPrivateKey() PrivateKey {
+	priv, _ := ecdsa.GenerateKey(self.curve, rand.Reader)
+
+	return &Secp256r1PrivateKey{pad(priv.D.Bytes(), 32)}
+}
+
+// Produces the compressed public key for the given private key. An
+// invalid private key produces an empty public key.
+func (self *Secp256r1Context) GetPublicKey(private_key PrivateKey) PublicKey {
+	priv, err := parseSecp256r1PrivateKey(private_key.AsBytes())
+	if err != nil {
+		return NewSecp256r1PublicKey(nil)
+	}
+
+	return NewSecp256r1PublicKey(compressSecp256r1(priv.X, priv.Y))
+}
+
+// Sign uses the given private key to calculate a signature for the
+// given data. A sha256 hash of the data is first calculated and this
+// is what is actually signed. Returns the signature as bytes using
+// the compact serialization (which is just (r, s)), or an error if
+// the private key is not a valid secp256r1 scalar.
+func (self *Secp256r1Context) Sign(message []byte, private_key PrivateKey) ([]byte, error) {
+	priv, err := parseSecp256r1PrivateKey(private_key.AsBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	r, s, err := ecdsa.Sign(rand.Reader, priv, doSHA256(message))
+	if err != nil {
+		return nil, errors.NewSigningError(err)
+	}
+
+	return append(pad(r.Bytes(), 32), pad(s.Bytes(), 32)...), nil
+}
+
+// Verify uses the given public key to verify that the given signature
+// was created from the given data using the associated private key. A
+// sha256 hash of the data is calculated first and this is what is
+// actually used to verify the signature. Returns an error if the
+// signature is not 64 bytes or the public key is not a valid point.
+func (self *Secp256r1Context) Verify(signature []byte, message []byte, public_key PublicKey) (bool, error) {
+	if len(signature) != 64 {
+		return false, errors.NewMalformedSignatureError(len(signature), 64)
+	}
+	pub, err := parseSecp256r1PublicKey(public_key.AsBytes())
+	if err != nil {
+		return false, err
+	}
+
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+
+	return ecdsa.Verify(pub, doSHA256(message), r, s), nil
+}
+
+// ---
+
+func parseSecp256r1PrivateKey(b []byte) (*ecdsa.PrivateKey, error) {
+	curve := elliptic.P256()
+	if len(b) != 32 {
+		return nil, errors.NewInvalidPrivateKeyError("secp256r1 private keys must be 32 bytes")
+	}
+	d := new(big.Int).SetBytes(b)
+	if d.Sign() == 0 || d.Cmp(curve.Params().N) >= 0 {
+		return nil, errors.NewInvalidPrivateKeyError("secp256r1 private key is out of range")
+	}
+
+	priv := &ecdsa.PrivateKey{D: d}
+	priv.Curve = curve
+	priv.X, priv.Y = curve.ScalarBaseMult(b)
+	return priv, nil
+}
+
+// parseSecp256r1PublicKey parses a compressed or uncompressed public key,
+// rejecting encodings that do not name a point on the curve
+func parseSecp256r1PublicKey(b []byte) (*ecdsa.PublicKey, error) {
+	curve := elliptic.P256()
+	params := curve.Params()
+	invalid := errors.NewInvalidPublicKeyError(hex.EncodeToString(b))
+
+	var x, y *big.Int
+	switch {
+	case len(b) == 65 && b[0] == 0x04:
+		x, y = elliptic.Unmarshal(curve, b)
+		if x == nil {
+			return nil, invalid
+		}
+	case len(b) == 33 && (b[0] == 0x02 || b[0] == 0x03):
+		x = new(big.Int).SetBytes(b[1:])
+		if x.Cmp(params.P) >= 0 {
+			return nil, invalid
+		}
+		// y^2 = x^3 - 3x + b
+		y2 := new(big.Int).Exp(x, big.NewInt(3), params.P)
+		threeX := new(big.Int).Lsh(x, 1)
+		threeX.Add(threeX, x)
+		y2.Sub(y2, threeX)
+		y2.Add(y2, params.B)
+		y2.Mod(y2, params.P)
+		y = new(big.Int).ModSqrt(y2, params.P)
+		if y == nil {
+			return nil, invalid
+		}
+		if y.Bit(0) != uint(b[0]&1) {
+			y.Sub(params.P, y)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, invalid
+		}
+	default:
+		return nil, invalid
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func compressSecp256r1(x, y *big.Int) []byte {
+	prefix := byte(0x02)
+	if y.Bit(0) == 1 {
+		prefix = 0x03
+	}
+	return append([]byte{prefix}, pad(x.Bytes(), 32)...)
+}
@@ -0,0 +1,139 @@
+/**
+ * Copyright 2020 Tyson Foods, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ------------------------------------------------------------------------------
+ */
+
+package tests
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+// uncachedKey hides the concrete key type so the context cannot reuse
+// anything it parsed or derived before
+type uncachedKey struct {
+	signing.PrivateKey
+}
+
+func TestCachedPublicKey(t *testing.T) {
+	context := signing.NewSecp256k1Context()
+	priv := context.NewRandomPrivateKey()
+
+	expected := context.GetPublicKey(&uncachedKey{priv}).AsHex()
+	for i := 0; i < 3; i++ {
+		if pub := context.GetPublicKey(priv).AsHex(); pub != expected {
+			t.Errorf("Cached public key %s; expected %s", pub, expected)
+		}
+	}
+
+	signer := signing.NewCryptoFactory(context).NewSigner(priv)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if pub := signer.GetPublicKey().AsHex(); pub != expected {
+				t.Errorf("Signer public key %s; expected %s", pub, expected)
+			}
+		}()
+	}
+	wg.Wait()
+
+	sig, err := context.Sign(data, &uncachedKey{priv})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached, err := signer.Sign(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(sig) != string(cached) {
+		t.Error("Cached and uncached keys produced different signatures")
+	}
+}
+
+func TestCachedPublicKeyIsCopied(t *testing.T) {
+	context := signing.NewSecp256k1Context()
+	priv := context.NewRandomPrivateKey()
+	signer := signing.NewCryptoFactory(context).NewSigner(priv)
+	expected := context.GetPublicKey(priv).AsHex()
+
+	context.GetPublicKey(priv).AsBytes()[0] ^= 0xff
+	signer.GetPublicKey().AsBytes()[0] ^= 0xff
+
+	if actual := context.GetPublicKey(priv).AsHex(); actual != expected {
+		t.Errorf("Context's cached public key changed to %s", actual)
+	}
+	if actual := signer.GetPublicKey().AsHex(); actual != expected {
+		t.Errorf("Signer's cached public key changed to %s", actual)
+	}
+}
+
+func BenchmarkSign(b *testing.B) {
+	context := signing.NewSecp256k1Context()
+	priv := context.NewRandomPrivateKey()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := context.Sign(data, priv); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSignUncached(b *testing.B) {
+	context := signing.NewSecp256k1Context()
+	priv := &uncachedKey{context.NewRandomPrivateKey()}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := context.Sign(data, priv); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetPublicKey(b *testing.B) {
+	context := signing.NewSecp256k1Context()
+	priv := context.NewRandomPrivateKey()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		context.GetPublicKey(priv)
+	}
+}
+
+func BenchmarkGetPublicKeyUncached(b *testing.B) {
+	context := signing.NewSecp256k1Context()
+	priv := &uncachedKey{context.NewRandomPrivateKey()}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		context.GetPublicKey(priv)
+	}
+}
+
+func BenchmarkSignerGetPublicKey(b *testing.B) {
+	context := signing.NewSecp256k1Context()
+	signer := signing.NewCryptoFactory(context).NewSigner(context.NewRandomPrivateKey())
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		signer.GetPublicKey()
+	}
+}
@@ -0,0 +1,201 @@
+/**
+ * Copyright 2020 Tyson Foods, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ------------------------------------------------------------------------------
+ */
+
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+func TestContextsSignAndVerify(t *testing.T) {
+	for _, name := range []string{"secp256k1", "secp256r1", "ed25519"} {
+		context := signing.CreateContext(name)
+		if context.GetAlgorithmName() != name {
+			t.Errorf("%s: got context for %s", name, context.GetAlgorithmName())
+		}
+
+		priv := context.NewRandomPrivateKey()
+		pub := context.GetPublicKey(priv)
+		if priv.GetAlgorithmName() != name || pub.GetAlgorithmName() != name {
+			t.Errorf("%s: keys report the wrong algorithm", name)
+		}
+
+		sig, err := context.Sign(data, priv)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		if len(sig) != 64 {
+			t.Errorf("%s: expected a 64 byte signature, got %d", name, len(sig))
+		}
+
+		valid, err := context.Verify(sig, data, pub)
+		if err != nil || !valid {
+			t.Errorf("%s: signature did not verify: %v", name, err)
+		}
+		valid, err = context.Verify(sig, []byte("other data"), pub)
+		if err != nil || valid {
+			t.Errorf("%s: signature verified for different data", name)
+		}
+
+		if _, err := context.Verify(sig[:10], data, pub); err == nil {
+			t.Errorf("%s: expected an error for a short signature", name)
+		}
+
+		restoredPriv, err := signing.NewPrivateKey(name, priv.AsBytes())
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		restoredPub, err := signing.NewPublicKey(name, pub.AsBytes())
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		if !reflect.DeepEqual(context.GetPublicKey(restoredPriv).AsBytes(), restoredPub.AsBytes()) {
+			t.Errorf("%s: restored keys do not match", name)
+		}
+	}
+}
+
+func TestInvalidKeysForContexts(t *testing.T) {
+	for _, name := range []string{"secp256k1", "secp256r1", "ed25519"} {
+		if _, err := signing.NewPrivateKey(name, []byte{0x01}); err == nil {
+			t.Errorf("%s: expected an error for a short private key", name)
+		}
+		if _, err := signing.NewPublicKey(name, []byte{0x02, 0x03}); err == nil {
+			t.Errorf("%s: expected an error for a short public key", name)
+		}
+	}
+
+	if _, err := signing.NewPrivateKey("rot13", []byte{0x01}); err == nil {
+		t.Error("Expected an error for an unregistered algorithm")
+	}
+}
+
+type reverseContext struct{}
+
+func (self *reverseContext) GetAlgorithmName() string { return "reverse" }
+
+func (self *reverseContext) NewRandomPrivateKey() signing.PrivateKey {
+	key, _ := signing.NewPrivateKey("reverse", []byte{0x01, 0x02})
+	return key
+}
+
+func (self *reverseContext) GetPublicKey(private_key signing.PrivateKey) signing.PublicKey {
+	key, _ := signing.NewPublicKey("reverse", private_key.AsBytes())
+	return key
+}
+
+func (self *reverseContext) Sign(message []byte, private_key signing.PrivateKey) ([]byte, error) {
+	sig := make([]byte, len(message))
+	for i, b := range message {
+		sig[len(message)-1-i] = b
+	}
+	return sig, nil
+}
+
+func (self *reverseContext) Verify(signature []byte, message []byte, public_key signing.PublicKey) (bool, error) {
+	sig, _ := self.Sign(message, nil)
+	return reflect.DeepEqual(sig, signature), nil
+}
+
+func TestRegisterContext(t *testing.T) {
+	if _, err := signing.NewContext("reverse"); err == nil {
+		t.Fatal("Expected reverse to be unregistered")
+	}
+	if _, ok := errorOf(signing.NewPrivateKey("reverse", nil)).(errors.UnknownAlgorithmError); !ok {
+		t.Error("Expected UnknownAlgorithmError before registration")
+	}
+
+	signing.RegisterContext("reverse", func() signing.Context { return &reverseContext{} })
+
+	context, err := signing.NewContext("reverse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := signing.NewCryptoFactory(context).NewSigner(context.NewRandomPrivateKey())
+	if signer.GetPublicKey().GetAlgorithmName() != "reverse" {
+		t.Error("Expected a public key for the registered algorithm")
+	}
+
+	sig, err := signer.Sign([]byte("abc"))
+	if err != nil || string(sig) != "cba" {
+		t.Errorf("Expected the registered context to sign, got %q %v", sig, err)
+	}
+
+	found := false
+	for _, name := range signing.RegisteredAlgorithms() {
+		if name == "reverse" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected reverse in RegisteredAlgorithms")
+	}
+}
+
+// countingContext wraps a built-in Context, counting the keys it parses
+type countingContext struct {
+	signing.Context
+	parsed int
+}
+
+func (self *countingContext) NewPrivateKey(private_key []byte) (signing.PrivateKey, error) {
+	self.parsed++
+	return self.Context.(signing.KeyParser).NewPrivateKey(private_key)
+}
+
+func (self *countingContext) NewPublicKey(public_key []byte) (signing.PublicKey, error) {
+	self.parsed++
+	return self.Context.(signing.KeyParser).NewPublicKey(public_key)
+}
+
+func TestReplacedContextParsesKeys(t *testing.T) {
+	defer signing.RegisterContext("ed25519", signing.NewEd25519Context)
+
+	counting := &countingContext{Context: signing.NewEd25519Context()}
+	signing.RegisterContext("ed25519", func() signing.Context { return counting })
+
+	private_key := signing.NewEd25519Context().NewRandomPrivateKey()
+	if _, err := signing.NewPrivateKey("ed25519", private_key.AsBytes()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signing.NewPublicKey("ed25519", []byte{0x01}); err == nil {
+		t.Error("Expected the replaced context to reject a short public key")
+	}
+	if counting.parsed != 2 {
+		t.Errorf("Expected the replaced context to parse 2 keys, got %d", counting.parsed)
+	}
+
+	// A replacement that does not parse keys receives them unvalidated
+	signing.RegisterContext("ed25519", func() signing.Context {
+		return struct{ signing.Context }{signing.NewEd25519Context()}
+	})
+	key, err := signing.NewPublicKey("ed25519", []byte{0x01})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.GetAlgorithmName() != "ed25519" || key.AsHex() != "01" {
+		t.Errorf("Expected the key bytes to pass through, got %s %s", key.GetAlgorithmName(), key.AsHex())
+	}
+}
+
+func errorOf(_ signing.PrivateKey, err error) error {
+	return err
+}
@@ -0,0 +1,139 @@
+/**
+ * Copyright 2020 Tyson Foods, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ------------------------------------------------------------------------------
+ */
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+func TestUnknownAlgorithm(t *testing.T) {
+	_, err := signing.NewContext("rot13")
+	if _, ok := err.(errors.UnknownAlgorithmError); !ok {
+		t.Error("Expected UnknownAlgorithmError, got", err)
+	}
+}
+
+func TestSignInvalidPrivateKey(t *testing.T) {
+	context := signing.NewSecp256k1Context()
+
+	keys := [][]byte{
+		[]byte("5dd1641e1e434387a9b870f47af2eb6a2150209b2712856aa1e7fb78e1426a58"),
+		make([]byte, 32),
+		{0x01},
+	}
+	for _, key := range keys {
+		_, err := context.Sign(data, signing.NewSecp256k1PrivateKey(key))
+		if _, ok := err.(errors.InvalidPrivateKeyError); !ok {
+			t.Error("Expected InvalidPrivateKeyError, got", err)
+		}
+
+		signer := signing.NewCryptoFactory(context).NewSigner(signing.NewSecp256k1PrivateKey(key))
+		if _, err := signer.Sign(data); err == nil {
+			t.Error("Expected Signer to return an error for an invalid key")
+		}
+	}
+}
+
+func TestVerifyMalformedInput(t *testing.T) {
+	context := signing.NewSecp256k1Context()
+	priv := context.NewRandomPrivateKey()
+	pub := context.GetPublicKey(priv)
+
+	sig, err := context.Sign(data, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = context.Verify(sig[:10], data, pub)
+	if _, ok := err.(errors.MalformedSignatureError); !ok {
+		t.Error("Expected MalformedSignatureError, got", err)
+	}
+
+	_, err = context.Verify(sig, data, signing.NewSecp256k1PublicKey([]byte{0x02, 0x01}))
+	if _, ok := err.(errors.InvalidPublicKeyError); !ok {
+		t.Error("Expected InvalidPublicKeyError, got", err)
+	}
+}
+
+func TestPemLoaderErrors(t *testing.T) {
+	if _, err := signing.PemToSecp256k1PrivateKey(ENCPEM, "wrong password"); err == nil {
+		t.Error("Expected an error decrypting PEM with the wrong password")
+	}
+
+	if _, err := signing.PemToSecp256k1PrivateKey(ENCPEM, ""); err == nil {
+		t.Error("Expected an error loading an encrypted PEM without a password")
+	}
+
+	_, err := signing.PemToSecp256k1PrivateKey("not a pem", "")
+	if _, ok := err.(errors.PemDecodingError); !ok {
+		t.Error("Expected PemDecodingError, got", err)
+	}
+
+	params := "-----BEGIN EC PARAMETERS-----\nBgUrgQQACg==\n-----END EC PARAMETERS-----\n"
+	_, err = signing.PemToSecp256k1PrivateKey(params, "")
+	if _, ok := err.(errors.PemDecodingError); !ok {
+		t.Error("Expected PemDecodingError for a PEM without a key, got", err)
+	}
+}
+
+func TestHexKeys(t *testing.T) {
+	priv, err := signing.NewSecp256k1PrivateKeyFromHex(PEMSTRPRIV + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signing.NewSecp256k1Context().GetPublicKey(priv).AsHex() != PEMPUBSTR {
+		t.Error("Hex private key produced the wrong public key")
+	}
+
+	invalidPrivateKeys := []string{
+		"not hex",
+		PEMSTRPRIV[:62],
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141",
+	}
+	for _, key := range invalidPrivateKeys {
+		_, err := signing.NewSecp256k1PrivateKeyFromHex(key)
+		if _, ok := err.(errors.InvalidPrivateKeyError); !ok {
+			t.Errorf("Expected InvalidPrivateKeyError for %q, got %v", key, err)
+		}
+	}
+
+	pub, err := signing.NewSecp256k1PublicKeyFromHex(PUBSTR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub.AsHex() != PUBSTR {
+		t.Error("Hex public key does not round trip")
+	}
+
+	invalidPublicKeys := []string{
+		"not hex",
+		PUBSTR[:64],
+		"04" + PUBSTR[2:],
+		"02ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	}
+	for _, key := range invalidPublicKeys {
+		_, err := signing.NewSecp256k1PublicKeyFromHex(key)
+		if _, ok := err.(errors.InvalidPublicKeyError); !ok {
+			t.Errorf("Expected InvalidPublicKeyError for %q, got %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+/**
+ * Copyright 2020 Tyson Foods, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * ------------------------------------------------------------------------------
+ */
+
+package tests
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+func TestRecoverPublicKey(t *testing.T) {
+	context := signing.NewSecp256k1Context().(*signing.Secp256k1Context)
+
+	for i := 0; i < 32; i++ {
+		priv := context.NewRandomPrivateKey()
+		pub := context.GetPublicKey(priv)
+
+		sig, err := context.SignRecoverable(data, priv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sig) != 65 || sig[64] > 3 {
+			t.Fatalf("Expected r || s || v with v in 0-3, got %x", sig)
+		}
+
+		compact, err := context.Sign(data, priv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(sig[:64], compact) {
+			t.Error("Expected the recoverable signature to start with the compact signature")
+		}
+
+		recovered, err := context.RecoverPublicKey(sig, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if recovered.AsHex() != pub.AsHex() {
+			t.Errorf("Recovered %s; expected %s", recovered.AsHex(), pub.AsHex())
+		}
+
+		other, err := context.RecoverPublicKey(sig, []byte("other data"))
+		if err == nil && other.AsHex() == pub.AsHex() {
+			t.Error("Recovered the signer's key for different data")
+		}
+	}
+}
+
+func TestRecoverGoldenVectors(t *testing.T) {
+	context := signing.NewSecp256k1Context().(*signing.Secp256k1Context)
+
+	for i, vector := range goldenVectors {
+		if vector.priv == "" {
+			continue
+		}
+		priv, err := signing.NewSecp256k1PrivateKeyFromHex(vector.priv)
+		if err != nil {
+			t.Fatal(i, err)
+		}
+
+		sig, err := context.SignRecoverable(vector.message, priv)
+		if err != nil {
+			t.Fatal(i, err)
+		}
+		if hex.EncodeToString(sig[:64]) != vector.signature {
+			t.Errorf("%d: wrong signature %x", i, sig[:64])
+		}
+
+		pub, err := context.RecoverPublicKey(sig, vector.message)
+		if err != nil {
+			t.Fatal(i, err)
+		}
+		if pub.AsHex() != vector.pub {
+			t.Errorf("%d: recovered %s", i, pub.AsHex())
+		}
+	}
+}
+
+func TestRecoverMalformedSignature(t *testing.T) {
+	context := signing.NewSecp256k1Context().(*signing.Secp256k1Context)
+	priv := context.NewRandomPrivateKey()
+
+	sig, err := context.SignRecoverable(data, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := context.RecoverPublicKey(sig[:64], data); err == nil {
+		t.Error("Expected an error for a signature without a recovery id")
+	} else if _, ok := err.(errors.MalformedSignatureError); !ok {
+		t.Error("Expected MalformedSignatureError, got", err)
+	}
+
+	badID := append([]byte{}, sig...)
+	badID[64] = 4
+	if _, err := context.RecoverPublicKey(badID, data); err == nil {
+		t.Error("Expected an error for an out of range recovery id")
+	} else if _, ok := err.(errors.PublicKeyRecoveryError); !ok {
+		t.Error("Expected PublicKeyRecoveryError, got", err)
+	}
+
+	zero := make([]byte, 65)
+	if _, err := context.RecoverPublicKey(zero, data); err == nil {
+		t.Error("Expected an error for a zero signature")
+	}
+
+	if _, err := context.SignRecoverable(data, signing.NewSecp256k1PrivateKey(make([]byte, 32))); err == nil {
+		t.Error("Expected an error for an invalid private key")
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transactions"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+func newSigner() *signing.Signer {
+	context := signing.NewSecp256k1Context()
+	return signing.NewCryptoFactory(context).NewSigner(context.NewRandomPrivateKey())
+}
+
+func buildTransaction(t *testing.T, signer signing.ISigner,
+	opts ...transactions.TransactionBuilderOption) *transaction_pb2.Transaction {
+	opts = append([]transactions.TransactionBuilderOption{
+		transactions.WithFamilyName("intkey"),
+		transactions.WithFamilyVersion("1.0"),
+		transactions.WithInputs([]string{"1cf126"}),
+		transactions.WithOutputs([]string{"1cf126"}),
+		transactions.WithPayload([]byte{0x01, 0x02, 0x03}),
+	}, opts...)
+
+	builder, err := transactions.NewTransactionBuilder(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txn, err := builder.Build(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return txn
+}
+
+func TestSeparateBatcher(t *testing.T) {
+	user := newSigner()
+	batcher := newSigner()
+
+	txn := buildTransaction(t, user, transactions.WithBatcherPublicKey(batcher.GetPublicKey()))
+
+	header := &transaction_pb2.TransactionHeader{}
+	if err := proto.Unmarshal(txn.GetHeader(), header); err != nil {
+		t.Fatal(err)
+	}
+	if header.GetSignerPublicKey() != user.GetPublicKey().AsHex() {
+		t.Error("Transaction header has wrong signer public key")
+	}
+	if header.GetBatcherPublicKey() != batcher.GetPublicKey().AsHex() {
+		t.Error("Transaction header has wrong batcher public key")
+	}
+
+	batchBuilder, err := transactions.NewBatchBuilder(
+		transactions.WithTransactions([]*transaction_pb2.Transaction{txn}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := batchBuilder.Build(batcher); err != nil {
+		t.Error("Failed to batch transaction with its batcher", err)
+	}
+
+	_, err = batchBuilder.Build(user)
+	if _, ok := err.(errors.BatcherMismatchError); !ok {
+		t.Error("Expected BatcherMismatchError, got", err)
+	}
+}
+
+func TestBatchList(t *testing.T) {
+	signer := newSigner()
+
+	listBuilder, err := transactions.NewBatchListBuilder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := listBuilder.Build(); err == nil {
+		t.Error("Expected an error building an empty batch list")
+	}
+
+	ids := []string{}
+	for i := 0; i < 3; i++ {
+		batchBuilder, err := transactions.NewBatchBuilder(
+			transactions.WithTransactions([]*transaction_pb2.Transaction{
+				buildTransaction(t, signer, transactions.WithNonce(string(rune('a'+i)))),
+			}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		batch, err := batchBuilder.BuildBatch(signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, batch.GetHeaderSignature())
+		listBuilder.AddBatches(batch)
+	}
+
+	listBytes, err := listBuilder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batchList := &transaction_pb2.BatchList{}
+	if err := proto.Unmarshal(listBytes, batchList); err != nil {
+		t.Fatal(err)
+	}
+	if len(batchList.GetBatches()) != len(ids) {
+		t.Fatalf("Expected %d batches, got %d", len(ids), len(batchList.GetBatches()))
+	}
+	for i, batch := range batchList.GetBatches() {
+		if batch.GetHeaderSignature() != ids[i] {
+			t.Error("Batch list does not preserve batch order")
+		}
+	}
+}
+
+// remoteSigner stands in for a signer whose private key is held elsewhere
+type remoteSigner struct {
+	publicKey signing.PublicKey
+	requests  chan []byte
+	responses chan []byte
+}
+
+func (r *remoteSigner) GetPublicKey() signing.PublicKey { return r.publicKey }
+
+func (r *remoteSigner) Sign(message []byte) ([]byte, error) {
+	r.requests <- message
+	return <-r.responses, nil
+}
+
+func TestExternalSigner(t *testing.T) {
+	backend := newSigner()
+	remote := &remoteSigner{
+		publicKey: backend.GetPublicKey(),
+		requests:  make(chan []byte),
+		responses: make(chan []byte),
+	}
+	go func() {
+		for message := range remote.requests {
+			signature, _ := backend.Sign(message)
+			remote.responses <- signature
+		}
+	}()
+	defer close(remote.requests)
+
+	txn := buildTransaction(t, remote)
+	batch := buildBatch(t, remote, txn)
+
+	if err := transactions.VerifyBatch(signing.NewSecp256k1Context(), batch); err != nil {
+		t.Error("Failed to verify batch signed by external signer", err)
+	}
+}
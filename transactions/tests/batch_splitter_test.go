@@ -0,0 +1,200 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transactions"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+func buildTransactions(t *testing.T, signer signing.ISigner, count int) []*transaction_pb2.Transaction {
+	txns := make([]*transaction_pb2.Transaction, count)
+	for i := range txns {
+		txns[i] = buildTransaction(t, signer, transactions.WithPayload([]byte(fmt.Sprint(i))))
+	}
+	return txns
+}
+
+func batchSizes(batches []*transaction_pb2.Batch) []int {
+	sizes := make([]int, len(batches))
+	for i, batch := range batches {
+		sizes[i] = len(batch.GetTransactions())
+	}
+	return sizes
+}
+
+func TestBatchSplitterMaxTransactions(t *testing.T) {
+	signer := newSigner()
+	txns := buildTransactions(t, signer, 10)
+
+	splitter, err := transactions.NewBatchSplitter(transactions.WithMaxBatchTransactions(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	splitter.Add(txns...)
+
+	batches, err := splitter.BuildBatches(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(batchSizes(batches)) != "[3 3 3 1]" {
+		t.Errorf("Unexpected batch sizes %v", batchSizes(batches))
+	}
+
+	context := signing.NewSecp256k1Context()
+	i := 0
+	for _, batch := range batches {
+		if err := transactions.VerifyBatch(context, batch); err != nil {
+			t.Error(err)
+		}
+		for _, txn := range batch.GetTransactions() {
+			if txn != txns[i] {
+				t.Errorf("Transaction %d is out of order", i)
+			}
+			i++
+		}
+	}
+}
+
+func TestBatchSplitterAtomic(t *testing.T) {
+	signer := newSigner()
+	txns := buildTransactions(t, signer, 6)
+
+	splitter, err := transactions.NewBatchSplitter(transactions.WithMaxBatchTransactions(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	splitter.Add(txns[0], txns[1])
+	splitter.AddAtomic(txns[2], txns[3], txns[4])
+	splitter.Add(txns[5])
+
+	batches, err := splitter.BuildBatches(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(batchSizes(batches)) != "[2 3 1]" {
+		t.Errorf("Unexpected batch sizes %v", batchSizes(batches))
+	}
+
+	splitter.AddAtomic(buildTransactions(t, signer, 4)...)
+	_, err = splitter.BuildBatches(signer)
+	if _, ok := err.(errors.BatchLimitExceededError); !ok {
+		t.Error("Expected BatchLimitExceededError, got", err)
+	}
+}
+
+func TestBatchSplitterMaxBytes(t *testing.T) {
+	signer := newSigner()
+	txns := buildTransactions(t, signer, 4)
+
+	unlimited, err := transactions.NewBatchSplitter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlimited.Add(txns[:2]...)
+	batches, err := unlimited.BuildBatches(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairSize := proto.Size(batches[0])
+
+	// The size estimate is exact, so a limit of exactly two transactions'
+	// worth packs pairs, and one byte less does not
+	for limit, expected := range map[int]string{pairSize: "[2 2]", pairSize - 1: "[1 1 1 1]"} {
+		splitter, err := transactions.NewBatchSplitter(transactions.WithMaxBatchBytes(limit))
+		if err != nil {
+			t.Fatal(err)
+		}
+		splitter.Add(txns...)
+
+		batches, err := splitter.BuildBatches(signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(batchSizes(batches)) != expected {
+			t.Errorf("limit %d: expected %s, got %v", limit, expected, batchSizes(batches))
+		}
+		for _, batch := range batches {
+			if proto.Size(batch) > limit {
+				t.Errorf("limit %d: batch is %d bytes", limit, proto.Size(batch))
+			}
+		}
+	}
+
+	splitter, err := transactions.NewBatchSplitter(transactions.WithMaxBatchBytes(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	splitter.Add(txns[0])
+	_, err = splitter.BuildBatches(signer)
+	if limitErr, ok := err.(errors.BatchLimitExceededError); !ok {
+		t.Error("Expected BatchLimitExceededError, got", err)
+	} else if limitErr.TransactionID() != txns[0].GetHeaderSignature() {
+		t.Error("Expected the error to name the oversized transaction")
+	}
+}
+
+func TestBatchSplitterBatchLists(t *testing.T) {
+	signer := newSigner()
+
+	splitter, err := transactions.NewBatchSplitter(
+		transactions.WithMaxBatchTransactions(1),
+		transactions.WithMaxBatchListBatches(2),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	splitter.Add(buildTransactions(t, signer, 5)...)
+
+	batchListsBytes, err := splitter.Build(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sizes []int
+	for _, batchListBytes := range batchListsBytes {
+		batchList := &transaction_pb2.BatchList{}
+		if err := proto.Unmarshal(batchListBytes, batchList); err != nil {
+			t.Fatal(err)
+		}
+		sizes = append(sizes, len(batchList.GetBatches()))
+	}
+	if fmt.Sprint(sizes) != "[2 2 1]" {
+		t.Errorf("Unexpected batch list sizes %v", sizes)
+	}
+}
+
+func TestBatchSplitterErrors(t *testing.T) {
+	_, err := transactions.NewBatchSplitter(transactions.WithMaxBatchTransactions(0))
+	if _, ok := err.(errors.InvalidBatchLimitError); !ok {
+		t.Error("Expected InvalidBatchLimitError, got", err)
+	}
+
+	splitter, err := transactions.NewBatchSplitter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = splitter.BuildBatches(newSigner())
+	if _, ok := err.(errors.MissingFieldError); !ok {
+		t.Error("Expected MissingFieldError, got", err)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	stderrors "errors"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transactions"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+func newBuilders(tb testing.TB, count int) []transactions.IBuildable {
+	builders := make([]transactions.IBuildable, count)
+	for i := range builders {
+		builder, err := transactions.NewTransactionBuilder(
+			transactions.WithFamilyName("intkey"),
+			transactions.WithFamilyVersion("1.0"),
+			transactions.WithInputs([]string{"1cf126"}),
+			transactions.WithOutputs([]string{"1cf126"}),
+			transactions.WithNonce(fmt.Sprint(i)),
+			transactions.WithPayload([]byte(fmt.Sprint(i))),
+		)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		builders[i] = builder
+	}
+	return builders
+}
+
+func TestBuildMany(t *testing.T) {
+	signer := newSigner()
+	context := signing.NewSecp256k1Context()
+
+	for _, workers := range []int{0, 1, 4, 200} {
+		txns, err := transactions.BuildMany(newBuilders(t, 100), signer, workers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(txns) != 100 {
+			t.Fatalf("Expected 100 transactions, got %d", len(txns))
+		}
+
+		for i, txn := range txns {
+			if string(txn.GetPayload()) != fmt.Sprint(i) {
+				t.Errorf("workers=%d: transaction %d is out of order", workers, i)
+			}
+			if err := transactions.VerifyTransaction(context, txn); err != nil {
+				t.Errorf("workers=%d: transaction %d failed verification: %v", workers, i, err)
+			}
+		}
+	}
+}
+
+func TestBuildManyEmpty(t *testing.T) {
+	txns, err := transactions.BuildMany(nil, newSigner(), 4)
+	if err != nil || len(txns) != 0 {
+		t.Error("Expected no transactions and no error", txns, err)
+	}
+}
+
+type failingBuilder struct{}
+
+func (f *failingBuilder) Build(signer signing.ISigner) (*transaction_pb2.Transaction, error) {
+	return nil, errors.NewMissingFieldError("Payload")
+}
+
+func TestBuildManyError(t *testing.T) {
+	builders := newBuilders(t, 10)
+	builders[3] = &failingBuilder{}
+
+	_, err := transactions.BuildMany(builders, newSigner(), 1)
+	buildErr, ok := err.(errors.TransactionBuildError)
+	if !ok {
+		t.Fatal("Expected TransactionBuildError, got", err)
+	}
+	if buildErr.Index() != 3 {
+		t.Errorf("Expected failure at index 3, got %d", buildErr.Index())
+	}
+
+	var missing errors.MissingFieldError
+	if !stderrors.As(err, &missing) {
+		t.Error("Expected TransactionBuildError to wrap MissingFieldError, got", buildErr.Unwrap())
+	}
+}
+
+func BenchmarkBuildSequential(b *testing.B) {
+	signer := newSigner()
+	builders := newBuilders(b, 1000)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, builder := range builders {
+			if _, err := builder.Build(signer); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkBuildMany reports BuildMany at 1, 2, 4 and 8 CPUs, setting
+// GOMAXPROCS and the worker count to match. Signing is CPU bound, so
+// each run should take roughly BenchmarkBuildSequential's time divided
+// by its CPU count, up to the number of physical cores on the machine.
+// Runs beyond that count show no further gain.
+func BenchmarkBuildMany(b *testing.B) {
+	signer := newSigner()
+	builders := newBuilders(b, 1000)
+
+	for _, procs := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("cpu=%d", procs), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				if _, err := transactions.BuildMany(builders, signer, procs); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBuildSequentialUnparsedKey(b *testing.B) {
+	context := signing.NewSecp256k1Context()
+	priv := context.NewRandomPrivateKey()
+	builders := newBuilders(b, 1000)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, builder := range builders {
+			// A fresh key each time must be parsed before every signature
+			signer := signing.NewCryptoFactory(context).NewSigner(
+				signing.NewSecp256k1PrivateKey(priv.AsBytes()))
+			if _, err := builder.Build(signer); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transactions"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+func newDependentBuilder(t *testing.T, payload string,
+	opts ...transactions.TransactionBuilderOption) transactions.IDependentBuildable {
+	opts = append([]transactions.TransactionBuilderOption{
+		transactions.WithFamilyName("intkey"),
+		transactions.WithFamilyVersion("1.0"),
+		transactions.WithInputs([]string{"1cf126"}),
+		transactions.WithOutputs([]string{"1cf126"}),
+		transactions.WithPayload([]byte(payload)),
+	}, opts...)
+
+	builder, err := transactions.NewTransactionBuilder(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dependent, ok := builder.(transactions.IDependentBuildable)
+	if !ok {
+		t.Fatal("TransactionBuilder does not implement IDependentBuildable")
+	}
+	return dependent
+}
+
+func dependencies(t *testing.T, txn *transaction_pb2.Transaction) []string {
+	header := &transaction_pb2.TransactionHeader{}
+	if err := proto.Unmarshal(txn.GetHeader(), header); err != nil {
+		t.Fatal(err)
+	}
+	return header.GetDependencies()
+}
+
+func TestGraphBuild(t *testing.T) {
+	signer := newSigner()
+	graph := transactions.NewGraph()
+
+	// Added in reverse so build order comes from the dependencies
+	for _, id := range []string{"d", "c", "b", "a"} {
+		if err := graph.AddNode(id, newDependentBuilder(t, id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, edge := range [][2]string{{"b", "a"}, {"c", "a"}, {"d", "b"}, {"d", "c"}} {
+		if err := graph.AddDependency(edge[0], edge[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	txns, err := graph.Build(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	built := make(map[string]*transaction_pb2.Transaction)
+	var order []string
+	for _, txn := range txns {
+		built[string(txn.GetPayload())] = txn
+		order = append(order, string(txn.GetPayload()))
+	}
+	if fmt.Sprint(order) != "[a c b d]" {
+		t.Errorf("Unexpected build order %v", order)
+	}
+
+	expected := map[string][]string{
+		"a": nil,
+		"b": {built["a"].GetHeaderSignature()},
+		"c": {built["a"].GetHeaderSignature()},
+		"d": {built["b"].GetHeaderSignature(), built["c"].GetHeaderSignature()},
+	}
+	for id, deps := range expected {
+		if fmt.Sprint(dependencies(t, built[id])) != fmt.Sprint(deps) {
+			t.Errorf("%s: expected dependencies %v, got %v", id, deps, dependencies(t, built[id]))
+		}
+	}
+
+	context := signing.NewSecp256k1Context()
+	for _, txn := range txns {
+		if err := transactions.VerifyTransaction(context, txn); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestGraphKeepsBuilderDependencies(t *testing.T) {
+	graph := transactions.NewGraph()
+	if err := graph.AddNode("a", newDependentBuilder(t, "a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.AddNode("b", newDependentBuilder(t, "b", transactions.WithDependencies([]string{"external"}))); err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.AddDependency("b", "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	txns, err := graph.Build(newSigner())
+	if err != nil {
+		t.Fatal(err)
+	}
+	deps := dependencies(t, txns[1])
+	if len(deps) != 2 || deps[0] != "external" || deps[1] != txns[0].GetHeaderSignature() {
+		t.Errorf("Unexpected dependencies %v", deps)
+	}
+}
+
+func TestGraphErrors(t *testing.T) {
+	graph := transactions.NewGraph()
+	for _, id := range []string{"a", "b", "c", "free"} {
+		if err := graph.AddNode(id, newDependentBuilder(t, id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, ok := graph.AddNode("a", newDependentBuilder(t, "a")).(errors.DuplicateNodeError); !ok {
+		t.Error("Expected DuplicateNodeError")
+	}
+	if _, ok := graph.AddDependency("a", "missing").(errors.UnknownNodeError); !ok {
+		t.Error("Expected UnknownNodeError")
+	}
+
+	for _, edge := range [][2]string{{"a", "b"}, {"b", "a"}, {"c", "a"}} {
+		if err := graph.AddDependency(edge[0], edge[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := graph.Build(newSigner())
+	cycleErr, ok := err.(errors.DependencyCycleError)
+	if !ok {
+		t.Fatal("Expected DependencyCycleError, got", err)
+	}
+	if fmt.Sprint(cycleErr.NodeIDs()) != "[a b c]" {
+		t.Errorf("Unexpected cycle nodes %v", cycleErr.NodeIDs())
+	}
+}
+
+func TestGraphBuildBatches(t *testing.T) {
+	signer := newSigner()
+	graph := transactions.NewGraph()
+	previous := ""
+	for i := 0; i < 5; i++ {
+		id := fmt.Sprint(i)
+		if err := graph.AddNode(id, newDependentBuilder(t, id)); err != nil {
+			t.Fatal(err)
+		}
+		if previous != "" {
+			if err := graph.AddDependency(id, previous); err != nil {
+				t.Fatal(err)
+			}
+		}
+		previous = id
+	}
+
+	batches, err := graph.BuildBatches(signer, transactions.WithMaxBatchTransactions(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(batchSizes(batches)) != "[2 2 1]" {
+		t.Errorf("Unexpected batch sizes %v", batchSizes(batches))
+	}
+
+	context := signing.NewSecp256k1Context()
+	for _, batch := range batches {
+		if err := transactions.VerifyBatch(context, batch); err != nil {
+			t.Error(err)
+		}
+	}
+}
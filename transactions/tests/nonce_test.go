@@ -0,0 +1,101 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transactions"
+)
+
+func TestDefaultNonceIsUnique(t *testing.T) {
+	signer := newSigner()
+
+	first := buildTransaction(t, signer)
+	second := buildTransaction(t, signer)
+
+	if first.GetHeaderSignature() == second.GetHeaderSignature() {
+		t.Error("Identical transactions without a nonce have the same header signature")
+	}
+}
+
+func TestNonceGenerators(t *testing.T) {
+	counter := transactions.NewCounterNonceGenerator(5)
+	for _, expected := range []string{"5", "6", "7"} {
+		nonce, err := counter.NextNonce()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if nonce != expected {
+			t.Errorf("Expected counter nonce %s, got %s", expected, nonce)
+		}
+	}
+
+	clock := transactions.NewTimeNonceGenerator()
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		nonce, err := clock.NextNonce()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen[nonce] {
+			t.Fatal("Time nonce generator repeated a nonce", nonce)
+		}
+		seen[nonce] = true
+	}
+
+	random := transactions.NewRandomNonceGenerator()
+	a, _ := random.NextNonce()
+	b, _ := random.NextNonce()
+	if a == b || len(a) != 32 {
+		t.Error("Random nonce generator produced", a, b)
+	}
+}
+
+func TestExplicitNonceOverridesGenerator(t *testing.T) {
+	txn := buildTransaction(t, newSigner(),
+		transactions.WithNonce("fixed"),
+		transactions.WithNonceGenerator(transactions.NewCounterNonceGenerator(0)),
+	)
+
+	header := &transaction_pb2.TransactionHeader{}
+	if err := proto.Unmarshal(txn.GetHeader(), header); err != nil {
+		t.Fatal(err)
+	}
+	if header.GetNonce() != "fixed" {
+		t.Error("Expected explicit nonce, got", header.GetNonce())
+	}
+}
+
+func TestTransactionBuilderNonceSource(t *testing.T) {
+	builder, err := transactions.NewTransactionBuilder(
+		transactions.WithNonceGenerator(transactions.NewCounterNonceGenerator(3)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, ok := builder.(transactions.INonceSource)
+	if !ok {
+		t.Fatal("TransactionBuilder does not implement INonceSource")
+	}
+	if nonce, err := source.NextNonce(); err != nil || nonce != "3" {
+		t.Errorf("Expected nonce 3, got %s, %v", nonce, err)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transactions"
+)
+
+func TestParseTransaction(t *testing.T) {
+	signer := newSigner()
+	txn := buildTransaction(t, signer)
+
+	parsed, err := transactions.ParseTransaction(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.ID != txn.GetHeaderSignature() || parsed.Transaction != txn {
+		t.Error("Parsed transaction does not match the transaction")
+	}
+	if parsed.Header.GetFamilyName() != "intkey" || parsed.Header.GetSignerPublicKey() != signer.GetPublicKey().AsHex() {
+		t.Error("Parsed transaction has the wrong header", parsed.Header)
+	}
+	if parsed.SabrePayload != nil {
+		t.Error("Expected no sabre payload for an intkey transaction")
+	}
+}
+
+func TestParseBatch(t *testing.T) {
+	signer := newSigner()
+	first := buildTransaction(t, signer)
+	second := buildTransaction(t, signer)
+	batch := buildBatch(t, signer, first, second)
+
+	parsed, err := transactions.ParseBatch(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.ID != batch.GetHeaderSignature() || parsed.Batch != batch {
+		t.Error("Parsed batch does not match the batch")
+	}
+	ids := parsed.Header.GetTransactionIds()
+	if len(ids) != 2 || ids[0] != first.GetHeaderSignature() || ids[1] != second.GetHeaderSignature() {
+		t.Error("Parsed batch header has the wrong transaction ids", ids)
+	}
+	if len(parsed.Transactions) != 2 || parsed.Transactions[1].ID != second.GetHeaderSignature() {
+		t.Error("Parsed batch has the wrong transactions")
+	}
+}
+
+func TestParseMalformedHeader(t *testing.T) {
+	signer := newSigner()
+	malformed := []byte{0xff, 0xff}
+
+	txn := proto.Clone(buildTransaction(t, signer)).(*transaction_pb2.Transaction)
+	txn.Header = malformed
+	if _, err := transactions.ParseTransaction(txn); err == nil {
+		t.Error("Expected an error parsing a malformed transaction header")
+	} else if _, ok := err.(errors.ProtobufDecodingError); !ok {
+		t.Errorf("Expected ProtobufDecodingError, got %v", err)
+	}
+
+	batch := buildBatch(t, signer, buildTransaction(t, signer))
+	badHeader := proto.Clone(batch).(*transaction_pb2.Batch)
+	badHeader.Header = malformed
+	if _, err := transactions.ParseBatch(badHeader); err == nil {
+		t.Error("Expected an error parsing a malformed batch header")
+	} else if _, ok := err.(errors.ProtobufDecodingError); !ok {
+		t.Errorf("Expected ProtobufDecodingError, got %v", err)
+	}
+
+	badTxn := proto.Clone(batch).(*transaction_pb2.Batch)
+	badTxn.Transactions[0].Header = malformed
+	if _, err := transactions.ParseBatch(badTxn); err == nil {
+		t.Error("Expected an error parsing a batch with a malformed transaction header")
+	}
+}
+
+func TestParseUndecodableSabrePayload(t *testing.T) {
+	txn := buildTransaction(t, newSigner(),
+		transactions.WithFamilyName("sabre"),
+		transactions.WithFamilyVersion("0.4"),
+		transactions.WithPayload([]byte{0xff, 0xff}),
+	)
+
+	parsed, err := transactions.ParseTransaction(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Header.GetFamilyName() != "sabre" {
+		t.Error("Parsed transaction has the wrong family name", parsed.Header.GetFamilyName())
+	}
+	if parsed.SabrePayload != nil {
+		t.Error("Expected no sabre payload for an undecodable payload")
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transactions"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+func buildBatch(t *testing.T, signer signing.ISigner, txns ...*transaction_pb2.Transaction) *transaction_pb2.Batch {
+	batchBuilder, err := transactions.NewBatchBuilder(transactions.WithTransactions(txns))
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch, err := batchBuilder.BuildBatch(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return batch
+}
+
+func TestVerify(t *testing.T) {
+	context := signing.NewSecp256k1Context()
+	signer := newSigner()
+	first := buildTransaction(t, signer)
+	second := buildTransaction(t, signer)
+	batch := buildBatch(t, signer, first, second)
+
+	if err := transactions.VerifyTransaction(context, first); err != nil {
+		t.Error("Failed to verify valid transaction", err)
+	}
+	if err := transactions.VerifyBatch(context, batch); err != nil {
+		t.Error("Failed to verify valid batch", err)
+	}
+
+	tampered := proto.Clone(first).(*transaction_pb2.Transaction)
+	tampered.Payload = []byte{0x04}
+	if _, ok := transactions.VerifyTransaction(context, tampered).(errors.PayloadHashMismatchError); !ok {
+		t.Error("Expected PayloadHashMismatchError for tampered payload")
+	}
+
+	forged := proto.Clone(first).(*transaction_pb2.Transaction)
+	forged.HeaderSignature = second.GetHeaderSignature()
+	if _, ok := transactions.VerifyTransaction(context, forged).(errors.InvalidSignatureError); !ok {
+		t.Error("Expected InvalidSignatureError for forged signature")
+	}
+
+	reordered := proto.Clone(batch).(*transaction_pb2.Batch)
+	reordered.Transactions = []*transaction_pb2.Transaction{second, first}
+	if _, ok := transactions.VerifyBatch(context, reordered).(errors.TransactionIDMismatchError); !ok {
+		t.Error("Expected TransactionIDMismatchError for reordered transactions")
+	}
+
+	truncated := proto.Clone(batch).(*transaction_pb2.Batch)
+	truncated.Transactions = truncated.Transactions[:1]
+	if _, ok := transactions.VerifyBatch(context, truncated).(errors.TransactionIDMismatchError); !ok {
+		t.Error("Expected TransactionIDMismatchError for missing transaction")
+	}
+
+	other := newSigner()
+	foreign := buildTransaction(t, other)
+	injected := buildBatch(t, other, foreign)
+	injected.Transactions = []*transaction_pb2.Transaction{first}
+	if _, ok := transactions.VerifyBatch(context, injected).(errors.TransactionIDMismatchError); !ok {
+		t.Error("Expected TransactionIDMismatchError for substituted transaction")
+	}
+}
@@ -36,7 +36,7 @@ type ITransactionBuilder interface {
 	GetOutputs() []string
 	GetNonce() string
 	GetPayload() []byte
-	Build(*signing.Signer) (*transaction_pb2.Transaction, error)
+	Build(signing.ISigner) (*transaction_pb2.Transaction, error)
 }
 
 // NewTransactionBuilder creates a TransactionBuilder from provided TransactionBuilderOptions
@@ -60,10 +60,12 @@ type TransactionBuilder struct {
 	inputs           []string
 	outputs          []string
 	nonce            string
+	nonceGenerator   INonceGenerator
 	payload          []byte
 }
 
-// GetBatcherPublicKey returns the transaction builder's batcher public key
+// GetBatcherPublicKey returns the transaction builder's batcher public key,
+// or nil if the transaction signer is also the batcher
 func (t *TransactionBuilder) GetBatcherPublicKey() signing.PublicKey { return t.batcherPublicKey }
 
 // GetDependencies returns the transaction builder's dependencies as a slice of string
@@ -84,10 +86,28 @@ func (t *TransactionBuilder) GetOutputs() []string { return t.outputs }
 // GetNonce returns the transaction builder's nonce string value
 func (t *TransactionBuilder) GetNonce() string { return t.nonce }
 
+// GetNonceGenerator returns the transaction builder's nonce generator,
+// or nil if the default random generator is used
+func (t *TransactionBuilder) GetNonceGenerator() INonceGenerator { return t.nonceGenerator }
+
+// NextNonce returns the nonce for the next built transaction. A nonce set
+// with WithNonce is returned verbatim; otherwise a new nonce is drawn from
+// the nonce generator, defaulting to a random nonce.
+func (t *TransactionBuilder) NextNonce() (string, error) {
+	if t.nonce != "" {
+		return t.nonce, nil
+	}
+	generator := t.nonceGenerator
+	if generator == nil {
+		generator = NewRandomNonceGenerator()
+	}
+	return generator.NextNonce()
+}
+
 // GetPayload returns the transaction builder's payload bytes
 func (t *TransactionBuilder) GetPayload() []byte { return t.payload }
 
-func (t *TransactionBuilder) buildTransactionHeader(signer *signing.Signer) ([]byte, error) {
+func (t *TransactionBuilder) buildTransactionHeader(signer signing.ISigner, dependencies []string) ([]byte, error) {
 	if t.familyName == "" {
 		return nil, errors.NewMissingFieldError("family name")
 	}
@@ -104,20 +124,27 @@ func (t *TransactionBuilder) buildTransactionHeader(signer *signing.Signer) ([]b
 		return nil, errors.NewMissingFieldError("payload")
 	}
 
-	signingKey := t.batcherPublicKey
-	if signingKey == nil {
-		signingKey = signer.GetPublicKey()
+	nonce, err := t.NextNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	signingKey := signer.GetPublicKey()
+	batcherKey := t.batcherPublicKey
+	if batcherKey == nil {
+		batcherKey = signingKey
 	}
 
 	header := &transaction_pb2.TransactionHeader{
-		FamilyName:      t.familyName,
-		FamilyVersion:   t.familyVersion,
-		Inputs:          t.inputs,
-		Outputs:         t.outputs,
-		SignerPublicKey: signingKey.AsHex(),
-		Dependencies:    t.dependencies,
-		Nonce:           t.nonce,
-		PayloadSha512:   crypto.NewSha512Hash(t.payload),
+		FamilyName:       t.familyName,
+		FamilyVersion:    t.familyVersion,
+		Inputs:           t.inputs,
+		Outputs:          t.outputs,
+		SignerPublicKey:  signingKey.AsHex(),
+		BatcherPublicKey: batcherKey.AsHex(),
+		Dependencies:     dependencies,
+		Nonce:            nonce,
+		PayloadSha512:    crypto.NewSha512Hash(t.payload),
 	}
 
 	headerBytes, err := proto.Marshal(header)
@@ -131,13 +158,29 @@ func (t *TransactionBuilder) buildTransactionHeader(signer *signing.Signer) ([]b
 // Build creates a Transaction provided signer of the transaction.
 // Returns an Transaction and an error indicating missing fields
 // or proto marshalling errors, if any
-func (t *TransactionBuilder) Build(signer *signing.Signer) (*transaction_pb2.Transaction, error) {
-	header, err := t.buildTransactionHeader(signer)
+func (t *TransactionBuilder) Build(signer signing.ISigner) (*transaction_pb2.Transaction, error) {
+	return t.build(signer, t.dependencies)
+}
+
+// BuildWithDependencies creates a Transaction in the same way as Build,
+// adding the given transaction IDs to the builder's dependencies. The
+// builder itself is not modified.
+func (t *TransactionBuilder) BuildWithDependencies(signer signing.ISigner, dependencies []string) (*transaction_pb2.Transaction, error) {
+	return t.build(signer, MergeDependencies(t.dependencies, dependencies))
+}
+
+func (t *TransactionBuilder) build(signer signing.ISigner, dependencies []string) (*transaction_pb2.Transaction, error) {
+	header, err := t.buildTransactionHeader(signer, dependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signer.Sign(header)
 	if err != nil {
 		return nil, err
 	}
 
-	headerSignature := hex.EncodeToString(signer.Sign(header))
+	headerSignature := hex.EncodeToString(signature)
 
 	return &transaction_pb2.Transaction{
 		Header:          header,
@@ -153,4 +196,5 @@ func (t *TransactionBuilder) setFamilyVersion(version string)           { t.fami
 func (t *TransactionBuilder) setInputs(inputs []string)                 { t.inputs = inputs }
 func (t *TransactionBuilder) setOutputs(outputs []string)               { t.outputs = outputs }
 func (t *TransactionBuilder) setNonce(nonce string)                     { t.nonce = nonce }
+func (t *TransactionBuilder) setNonceGenerator(g INonceGenerator)       { t.nonceGenerator = g }
 func (t *TransactionBuilder) setPayload(payload []byte)                 { t.payload = payload }
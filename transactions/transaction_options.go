@@ -23,7 +23,8 @@ import (
 type TransactionBuilderOption func(t *TransactionBuilder) error
 
 // WithBatcherPublicKey provides the TransactionBuilderOption for
-// defining a public key to sign batches
+// defining the public key of the batcher that will sign the batch
+// containing the transaction. Defaults to the transaction signer's key.
 func WithBatcherPublicKey(key signing.PublicKey) TransactionBuilderOption {
 	return func(t *TransactionBuilder) error {
 		t.setBatcherPublicKey(key)
@@ -85,6 +86,15 @@ func WithNonce(nonce string) TransactionBuilderOption {
 	}
 }
 
+// WithNonceGenerator provides the TransactionBuilderOption for
+// defining how nonces are generated when no nonce is set
+func WithNonceGenerator(generator INonceGenerator) TransactionBuilderOption {
+	return func(t *TransactionBuilder) error {
+		t.setNonceGenerator(generator)
+		return nil
+	}
+}
+
 // WithPayload provides the TransactionBuilderOption for
 // defining transaction payload bytes
 func WithPayload(payload []byte) TransactionBuilderOption {
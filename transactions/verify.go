@@ -0,0 +1,132 @@
+// Copyright 2020 Tyson Foods, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -----------------------------------------------------------------------------
+
+package transactions
+
+import (
+	"encoding/hex"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/transact-sdk-go/crypto"
+	"github.com/hyperledger/transact-sdk-go/errors"
+	"github.com/hyperledger/transact-sdk-go/src/protobuf/transaction_pb2"
+	"github.com/hyperledger/transact-sdk-go/transactions/signing"
+)
+
+// VerifyTransaction checks that a transaction's payload matches the
+// payload_sha512 of its header and that the header was signed by the
+// header's signer, using the given signing context.
+// Returns nil if the transaction is valid, otherwise a typed error from
+// the errors package naming the check that failed
+func VerifyTransaction(context signing.Context, txn *transaction_pb2.Transaction) error {
+	_, err := verifyTransaction(context, txn)
+	return err
+}
+
+// VerifyBatch checks that a batch's header was signed by the header's
+// signer, that its transaction_ids match the contained transactions in
+// order, and that every transaction is valid and names the batch signer
+// as its batcher.
+// Returns nil if the batch is valid, otherwise a typed error from
+// the errors package naming the check that failed
+func VerifyBatch(context signing.Context, batch *transaction_pb2.Batch) error {
+	header := &transaction_pb2.BatchHeader{}
+	if err := proto.Unmarshal(batch.GetHeader(), header); err != nil {
+		return errors.NewProtobufDecodingError(err)
+	}
+
+	err := verifyHeaderSignature(context, batch.GetHeader(),
+		batch.GetHeaderSignature(), header.GetSignerPublicKey())
+	if err != nil {
+		return err
+	}
+
+	ids := header.GetTransactionIds()
+	txns := batch.GetTransactions()
+	for i := 0; i < len(ids) || i < len(txns); i++ {
+		var expected, actual string
+		if i < len(ids) {
+			expected = ids[i]
+		}
+		if i < len(txns) {
+			actual = txns[i].GetHeaderSignature()
+		}
+		if expected == "" || expected != actual {
+			return errors.NewTransactionIDMismatchError(batch.GetHeaderSignature(), i, expected, actual)
+		}
+	}
+
+	for _, txn := range txns {
+		txnHeader, err := verifyTransaction(context, txn)
+		if err != nil {
+			return err
+		}
+		if txnHeader.GetBatcherPublicKey() != header.GetSignerPublicKey() {
+			return errors.NewBatcherMismatchError(txn.GetHeaderSignature(),
+				header.GetSignerPublicKey(), txnHeader.GetBatcherPublicKey())
+		}
+	}
+
+	return nil
+}
+
+func verifyTransaction(context signing.Context,
+	txn *transaction_pb2.Transaction) (*transaction_pb2.TransactionHeader, error) {
+	header := &transaction_pb2.TransactionHeader{}
+	if err := proto.Unmarshal(txn.GetHeader(), header); err != nil {
+		return nil, errors.NewProtobufDecodingError(err)
+	}
+
+	if header.GetPayloadSha512() != crypto.NewSha512Hash(txn.GetPayload()) {
+		return nil, errors.NewPayloadHashMismatchError(txn.GetHeaderSignature())
+	}
+
+	err := verifyHeaderSignature(context, txn.GetHeader(),
+		txn.GetHeaderSignature(), header.GetSignerPublicKey())
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func verifyHeaderSignature(context signing.Context, header []byte,
+	headerSignature string, signerPublicKey string) error {
+	publicKey, err := hex.DecodeString(signerPublicKey)
+	if err != nil || len(publicKey) == 0 {
+		return errors.NewInvalidPublicKeyError(signerPublicKey)
+	}
+
+	signature, err := hex.DecodeString(headerSignature)
+	if err != nil {
+		return errors.NewInvalidSignatureError(headerSignature)
+	}
+
+	key, err := signing.NewPublicKey(context.GetAlgorithmName(), publicKey)
+	if err != nil {
+		return errors.NewInvalidPublicKeyError(signerPublicKey)
+	}
+
+	valid, err := context.Verify(signature, header, key)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.NewInvalidSignatureError(headerSignature)
+	}
+
+	return nil
+}